@@ -1,19 +1,47 @@
 package pjlink
 
 import (
-	"bufio"
+	"context"
 	"errors"
+	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const pjLinkPort = "4352"
 
+// used when the timeouts of a PJProjector are left at zero
+const (
+	defaultDialTimeout  = 10 * time.Second
+	defaultReadTimeout  = 10 * time.Second
+	defaultWriteTimeout = 10 * time.Second
+)
+
+// PJProjector is safe for concurrent use, requests to the same device are sent one after another.
 type PJProjector struct {
 	Address  string
 	Port     string
-	Password string
+	Password string `json:"-"` // left out of JSON like it is hidden from %v
+	// consulted on each authentication challenge instead of Password if set, see EnvCredentials,
+	// FileCredentials and HostFileCredentials
+	Credentials CredentialProvider
+	// keep the TCP connection open across requests instead of dialing for every request.
+	// The connection is re-established transparently when it was dropped, call Close() when done.
+	Session bool
+	// PJLink class implemented by the Projector (see CLSS), 2 enables the Class 2 forms of INPT and INST
+	Class int
+	// timeouts for connecting, reading the greeting or a response and sending a command, zero means 10 seconds
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	// how often WaitForPower asks for the power state, zero means once a second
+	PowerPollInterval time.Duration
+	// receives the traffic on the wire with the authentication digest redacted, see SlogTracer and HexDumpTracer
+	Tracer Tracer
+
+	session *pjConn
 }
 
 func NewProjector(IP string, password string) *PJProjector {
@@ -24,64 +52,104 @@ func NewProjector(IP string, password string) *PJProjector {
 	}
 }
 
+// String describes the Projector without revealing its password, also used by %v
+func (pr PJProjector) String() string {
+	password := ""
+	if pr.Password != "" {
+		password = "[redacted]"
+	}
+	return fmt.Sprintf("{Address:%s Port:%s Password:%s Credentials:%t Session:%t Class:%d Tracer:%t}",
+		pr.Address, pr.Port, password, pr.Credentials != nil, pr.Session, pr.Class, pr.Tracer != nil)
+}
+
+// GoString is used by %#v, it hides the password as well
+func (pr PJProjector) GoString() string {
+	return "pjlink.PJProjector" + pr.String()
+}
+
 //--------------------------------------------------------------------------------------------------------------------//
 //--------------- Functional Calls -----------------------------------------------------------------------------------//
 //--------------------------------------------------------------------------------------------------------------------//
 
 //--------------- Power ----------------------------------------------------------------------------------------------//
 func (pr *PJProjector) GetPowerStatus() (*PJResponse, error) {
+	return pr.GetPowerStatusContext(context.Background())
+}
+
+func (pr *PJProjector) GetPowerStatusContext(ctx context.Context) (*PJResponse, error) {
 	req := PJRequest{
 		Class:     1,
 		Command:   "POWR",
 		Parameter: "?",
 	}
-	return pr.SendRequest(req)
+	return pr.SendRequestContext(ctx, req)
 }
 
 func (pr *PJProjector) TurnOn() (error) {
+	return pr.TurnOnContext(context.Background())
+}
+
+func (pr *PJProjector) TurnOnContext(ctx context.Context) (error) {
 	req := PJRequest{
 		Class:     1,
 		Command:   "POWR",
 		Parameter: "1",
 	}
-	resp, err := pr.SendRequest(req)
+	resp, err := pr.SendRequestContext(ctx, req)
 	if err != nil {
 		return err
 	}
 	if resp.Success(){
 		return nil
 	}
-	return errors.New("Could not turn on Projector")
+	if err := resp.Err(); err != nil {
+		return pr.annotate(err, req.Command)
+	}
+	return pr.annotate(&ResponseError{Response: strings.Join(resp.Response, " "), Reason: "Could not turn on Projector"}, req.Command)
 }
 
 func (pr *PJProjector) TurnOff() (error) {
+	return pr.TurnOffContext(context.Background())
+}
+
+func (pr *PJProjector) TurnOffContext(ctx context.Context) (error) {
 	req := PJRequest{
 		Class:     1,
 		Command:   "POWR",
 		Parameter: "0",
 	}
-	resp, err := pr.SendRequest(req)
+	resp, err := pr.SendRequestContext(ctx, req)
 	if err != nil {
 		return err
 	}
 	if resp.Success(){
 		return nil
 	}
-	return errors.New("Could not turn off Projector")
+	if err := resp.Err(); err != nil {
+		return pr.annotate(err, req.Command)
+	}
+	return pr.annotate(&ResponseError{Response: strings.Join(resp.Response, " "), Reason: "Could not turn off Projector"}, req.Command)
 }
 
 func (self *PJProjector) GetProperty(property string) (string, error) {
+	return self.GetPropertyContext(context.Background(), property)
+}
+
+func (self *PJProjector) GetPropertyContext(ctx context.Context, property string) (string, error) {
 	var request PJRequest
 
 	request.Class = 1
 	request.Command = property
 	request.Parameter = "?"
 
-	resp, err := self.SendRequest(request)
+	resp, err := self.SendRequestContext(ctx, request)
 
 	if err != nil {
 		return "", err
 	}
+	if err := resp.Err(); err != nil {
+		return "", self.annotate(err, property)
+	}
 
 	/*	log.Printf("response size for %s: %d\n", property, len(resp.Response))
 		for i := 0; i < len(resp.Response); i++ {
@@ -92,17 +160,24 @@ func (self *PJProjector) GetProperty(property string) (string, error) {
 }
 
 func (self *PJProjector) GetPropertyArray(property string) ([]string, error) {
+	return self.GetPropertyArrayContext(context.Background(), property)
+}
+
+func (self *PJProjector) GetPropertyArrayContext(ctx context.Context, property string) ([]string, error) {
 	var request PJRequest
 
 	request.Class = 1
 	request.Command = property
 	request.Parameter = "?"
 
-	resp, err := self.SendRequest(request)
+	resp, err := self.SendRequestContext(ctx, request)
 
 	if err != nil {
 		return make([]string, 0), err
 	}
+	if err := resp.Err(); err != nil {
+		return make([]string, 0), self.annotate(err, property)
+	}
 
 	/*	log.Printf("response size for %s: %d\n", property, len(resp.Response))
 		for i := 0; i < len(resp.Response); i++ {
@@ -113,102 +188,423 @@ func (self *PJProjector) GetPropertyArray(property string) ([]string, error) {
 }
 
 func (self *PJProjector) SetProperty(property string, val string) error {
+	return self.SetPropertyContext(context.Background(), property, val)
+}
+
+func (self *PJProjector) SetPropertyContext(ctx context.Context, property string, val string) error {
 	var request PJRequest
 
 	request.Class = 1
 	request.Command = property
 	request.Parameter = val
 
-	_, err := self.SendRequest(request)
+	resp, err := self.SendRequestContext(ctx, request)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return self.annotate(resp.Err(), property)
+}
+
+//--------------- Class 2 --------------------------------------------------------------------------------------------//
+
+// Resolution as reported by IRES and RRES
+type Resolution struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func (r Resolution) String() string {
+	return strconv.Itoa(r.Width) + "x" + strconv.Itoa(r.Height)
+}
+
+var (
+	// returned by GetInputResolution when there is no signal on the current input ("-")
+	ErrNoSignal = errors.New("no signal")
+	// returned by GetInputResolution when the signal on the current input can't be determined ("*")
+	ErrUnknownSignal = errors.New("unknown signal")
+)
+
+func (pr *PJProjector) GetSerialNumber() (string, error) {
+	return pr.GetSerialNumberContext(context.Background())
+}
+
+func (pr *PJProjector) GetSerialNumberContext(ctx context.Context) (string, error) {
+	resp, err := pr.query(ctx, 2, "SNUM", "?")
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(resp.Response, " "), nil
+}
+
+func (pr *PJProjector) GetSoftwareVersion() (string, error) {
+	return pr.GetSoftwareVersionContext(context.Background())
+}
+
+func (pr *PJProjector) GetSoftwareVersionContext(ctx context.Context) (string, error) {
+	resp, err := pr.query(ctx, 2, "SVER", "?")
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(resp.Response, " "), nil
+}
+
+// input is the two character input as used by INPT, e.g. "31" or "3A"
+func (pr *PJProjector) GetInputName(input string) (string, error) {
+	return pr.GetInputNameContext(context.Background(), input)
+}
+
+func (pr *PJProjector) GetInputNameContext(ctx context.Context, input string) (string, error) {
+	resp, err := pr.query(ctx, 2, "INNM", "?"+input)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(resp.Response, " "), nil
+}
+
+func (pr *PJProjector) GetInputResolution() (Resolution, error) {
+	return pr.GetInputResolutionContext(context.Background())
+}
+
+func (pr *PJProjector) GetInputResolutionContext(ctx context.Context) (Resolution, error) {
+	resp, err := pr.query(ctx, 2, "IRES", "?")
+	if err != nil {
+		return Resolution{}, err
+	}
+	switch resp.Response[0] {
+	case "-":
+		return Resolution{}, ErrNoSignal
+	case "*":
+		return Resolution{}, ErrUnknownSignal
+	}
+	res, err := parseResolution(resp.Response[0])
+	return res, pr.annotate(err, "IRES")
+}
+
+func (pr *PJProjector) GetRecommendedResolution() (Resolution, error) {
+	return pr.GetRecommendedResolutionContext(context.Background())
+}
+
+func (pr *PJProjector) GetRecommendedResolutionContext(ctx context.Context) (Resolution, error) {
+	resp, err := pr.query(ctx, 2, "RRES", "?")
+	if err != nil {
+		return Resolution{}, err
+	}
+	res, err := parseResolution(resp.Response[0])
+	return res, pr.annotate(err, "RRES")
+}
+
+// returns the filter usage time in hours
+func (pr *PJProjector) GetFilterUsage() (int, error) {
+	return pr.GetFilterUsageContext(context.Background())
+}
+
+func (pr *PJProjector) GetFilterUsageContext(ctx context.Context) (int, error) {
+	resp, err := pr.query(ctx, 2, "FILT", "?")
+	if err != nil {
+		return 0, err
+	}
+	hours, err := strconv.Atoi(resp.Response[0])
+	if err != nil {
+		return 0, pr.annotate(&ResponseError{Response: resp.Response[0], Reason: "Invalid filter usage time: " + resp.Response[0]}, "FILT")
+	}
+	return hours, nil
+}
+
+// returns the model numbers of replacement lamps, empty if the Projector doesn't know them
+func (pr *PJProjector) GetLampReplacementModels() ([]string, error) {
+	return pr.GetLampReplacementModelsContext(context.Background())
+}
+
+func (pr *PJProjector) GetLampReplacementModelsContext(ctx context.Context) ([]string, error) {
+	resp, err := pr.query(ctx, 2, "RLMP", "?")
+	if err != nil {
+		return nil, err
+	}
+	return replacementModels(resp), nil
+}
+
+// returns the model numbers of replacement filters, empty if the Projector doesn't know them
+func (pr *PJProjector) GetFilterReplacementModels() ([]string, error) {
+	return pr.GetFilterReplacementModelsContext(context.Background())
+}
+
+func (pr *PJProjector) GetFilterReplacementModelsContext(ctx context.Context) ([]string, error) {
+	resp, err := pr.query(ctx, 2, "RFIL", "?")
+	if err != nil {
+		return nil, err
+	}
+	return replacementModels(resp), nil
+}
+
+func (pr *PJProjector) SpeakerVolumeUp() error {
+	return pr.SpeakerVolumeUpContext(context.Background())
+}
+
+func (pr *PJProjector) SpeakerVolumeUpContext(ctx context.Context) error {
+	return pr.execute(ctx, 2, "SVOL", VolumeRequests["volume-up"])
+}
+
+func (pr *PJProjector) SpeakerVolumeDown() error {
+	return pr.SpeakerVolumeDownContext(context.Background())
+}
+
+func (pr *PJProjector) SpeakerVolumeDownContext(ctx context.Context) error {
+	return pr.execute(ctx, 2, "SVOL", VolumeRequests["volume-down"])
+}
+
+func (pr *PJProjector) MicrophoneVolumeUp() error {
+	return pr.MicrophoneVolumeUpContext(context.Background())
+}
+
+func (pr *PJProjector) MicrophoneVolumeUpContext(ctx context.Context) error {
+	return pr.execute(ctx, 2, "MVOL", VolumeRequests["volume-up"])
+}
+
+func (pr *PJProjector) MicrophoneVolumeDown() error {
+	return pr.MicrophoneVolumeDownContext(context.Background())
+}
+
+func (pr *PJProjector) MicrophoneVolumeDownContext(ctx context.Context) error {
+	return pr.execute(ctx, 2, "MVOL", VolumeRequests["volume-down"])
+}
+
+func (pr *PJProjector) GetFreeze() (bool, error) {
+	return pr.GetFreezeContext(context.Background())
+}
+
+func (pr *PJProjector) GetFreezeContext(ctx context.Context) (bool, error) {
+	resp, err := pr.query(ctx, 2, "FREZ", "?")
+	if err != nil {
+		return false, err
+	}
+	switch resp.Response[0] {
+	case FreezeRequests["freeze-on"]:
+		return true, nil
+	case FreezeRequests["freeze-off"]:
+		return false, nil
+	}
+	return false, pr.annotate(&ResponseError{Response: resp.Response[0], Reason: "Invalid freeze status: " + resp.Response[0]}, "FREZ")
+}
+
+func (pr *PJProjector) SetFreeze(on bool) error {
+	return pr.SetFreezeContext(context.Background(), on)
+}
+
+func (pr *PJProjector) SetFreezeContext(ctx context.Context, on bool) error {
+	if on {
+		return pr.execute(ctx, 2, "FREZ", FreezeRequests["freeze-on"])
+	}
+	return pr.execute(ctx, 2, "FREZ", FreezeRequests["freeze-off"])
+}
+
+// sends a query and turns an ERRx answer into an error
+func (pr *PJProjector) query(ctx context.Context, class int, command string, parameter string) (*PJResponse, error) {
+	resp, err := pr.SendRequestContext(ctx, PJRequest{
+		Class:     class,
+		Command:   command,
+		Parameter: parameter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, pr.annotate(err, command)
+	}
+	return resp, nil
+}
+
+// sends a query whose answer is free text which may contain spaces, such as NAME
+func (pr *PJProjector) queryText(ctx context.Context, class int, command string) (string, error) {
+	resp, err := pr.query(ctx, class, command, "?")
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(resp.Response, " "), nil
+}
+
+// sends a set command and turns anything but OK into an error
+func (pr *PJProjector) execute(ctx context.Context, class int, command string, parameter string) error {
+	resp, err := pr.query(ctx, class, command, parameter)
+	if err != nil {
+		return err
+	}
+	if !resp.Success() {
+		response := strings.Join(resp.Response, " ")
+		return pr.annotate(&ResponseError{Response: response, Reason: "unexpected response " + response}, command)
+	}
+	return nil
+}
+
+func parseResolution(raw string) (Resolution, error) {
+	width, height, found := strings.Cut(raw, "x")
+	if !found {
+		return Resolution{}, &ResponseError{Response: raw, Reason: "Invalid resolution: " + raw}
+	}
+	w, errW := strconv.Atoi(width)
+	h, errH := strconv.Atoi(height)
+	if errW != nil || errH != nil {
+		return Resolution{}, &ResponseError{Response: raw, Reason: "Invalid resolution: " + raw}
+	}
+	return Resolution{Width: w, Height: h}, nil
+}
+
+func replacementModels(resp *PJResponse) []string {
+	models := make([]string, 0, len(resp.Response))
+	for _, model := range resp.Response {
+		if model != "" {
+			models = append(models, model)
+		}
+	}
+	return models
 }
 
 //--------------------------------------------------------------------------------------------------------------------//
 // Low-Level Calls
 //--------------------------------------------------------------------------------------------------------------------//
 func (pr *PJProjector) SendRequest(request PJRequest) (*PJResponse, error) {
+	return pr.SendRequestContext(context.Background(), request)
+}
+
+func (pr *PJProjector) SendRequestContext(ctx context.Context, request PJRequest) (*PJResponse, error) {
 	if err := request.Validate(); err != nil { //malformed command, don't send
-		return nil, err
+		return nil, pr.annotate(err, request.Command)
 	} else { //send request and parse response into struct
-		response, requestError := pr.sendRawRequest(request)
+		release, err := pr.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		response, requestError := pr.sendRawRequest(ctx, request)
 		if requestError != nil {
-			return nil, requestError
+			return nil, pr.annotate(requestError, request.Command)
 		} else {
 			return response, nil
 		}
 	}
 }
 
-func (pr *PJProjector) sendRawRequest(request PJRequest) (*PJResponse, error) {
-	//establish TCP connection with PJLink device
-	connection, connectionError := pr.connectToPJLink()
-	defer connection.Close()
+func (pr *PJProjector) sendRawRequest(ctx context.Context, request PJRequest) (*PJResponse, error) {
+	if !pr.Session {
+		//establish TCP connection with PJLink device, used for this request only
+		connection, connectionError := pr.dial(ctx)
+		if connectionError != nil {
+			return nil, connectionError
+		}
+		defer connection.Close()
 
-	if connectionError != nil {
-		return nil, connectionError
+		return connection.exchange(ctx, request, pr.password)
 	}
 
-	// Define a split function that separates on carriage return (i.e '\r').
-	onCarriageReturn := func(data []byte, atEOF bool) (advance int, token []byte,
-		err error) {
-		for i := 0; i < len(data); i++ {
-			if data[i] == '\r' {
-				return i + 1, data[:i], nil
-			}
+	//reuse the open session, the projector drops it after 30 seconds of silence
+	reused := pr.session != nil && !pr.session.expired()
+	if pr.session != nil && !reused {
+		pr.session.Close()
+		pr.session = nil
+	}
+	if pr.session == nil {
+		connection, connectionError := pr.dial(ctx)
+		if connectionError != nil {
+			return nil, connectionError
 		}
-		// There is one final token to be delivered, which may be the empty string.
-		// Returning bufio.ErrFinalToken here tells Scan there are no more tokens
-		// after this but does not trigger an error to be returned from Scan itself.
-		return 0, data, bufio.ErrFinalToken
+		pr.session = connection
 	}
 
-	//setup scanner
-	scanner := bufio.NewScanner(connection)
-	scanner.Split(onCarriageReturn)
-	scanner.Scan() //grab a line
-	challenge := strings.Split(scanner.Text(), " ")
+	resp, err := pr.session.exchange(ctx, request, pr.password)
+	if err != nil && reused && pr.session.broken && contextError(ctx) == nil {
+		//the projector closed the link on its side, redial and authenticate again
+		pr.session.Close()
+		pr.session = nil
+		return pr.sendRawRequest(ctx, request)
+	}
+	if err != nil {
+		pr.session.Close()
+		pr.session = nil
+	}
+	return resp, err
+}
 
-	seed := pr.checkAuthentication(challenge)
-	stringCommand := request.toRaw(seed, pr.Password)
+// Closes the connection held open in Session mode. The Projector can still be used afterwards.
+func (pr *PJProjector) Close() error {
+	release, _ := pr.acquire(context.Background())
+	defer release()
 
+	if pr.session == nil {
+		return nil
+	}
+	err := pr.session.Close()
+	pr.session = nil
+	return err
+}
 
-	//send command
-	connection.Write([]byte(stringCommand))
-	scanner.Scan() //grab response line
+// connects to the PJLink device and reads the greeting
+func (pr *PJProjector) dial(ctx context.Context) (*pjConn, error) {
+	connection, connectionError := pr.connectToPJLink(ctx)
+	if connectionError != nil {
+		return nil, connectionError
+	}
 
-	resp := NewPJResponse()
-	err := resp.Parse(scanner.Text())
+	conn := newPJConn(connection, withDefault(pr.ReadTimeout, defaultReadTimeout), withDefault(pr.WriteTimeout, defaultWriteTimeout))
+	conn.tracer, conn.address = pr.Tracer, pr.hostPort()
+	greeting, err := conn.readLine(ctx)
 	if err != nil {
-		return resp, err
+		conn.trace(TraceGreeting, nil, err)
+		conn.Close()
+		return nil, &NetworkError{Op: "greeting", Err: err}
 	}
+	conn.trace(TraceGreeting, []byte(greeting+"\r"), nil)
+	conn.seed = pr.checkAuthentication(strings.Split(greeting, " "))
 
-	return resp, nil
+	return conn, nil
 }
 
 //attempts to establish a TCP socket with the specified IP:port
 //success: returns populated pjlinkConn struct and nil error
 //failure: returns empty pjlinkConn and error
-func (pr *PJProjector) connectToPJLink() (net.Conn, error) {
+func (pr *PJProjector) connectToPJLink(ctx context.Context) (net.Conn, error) {
 	protocol := "tcp" //PJLink always uses TCP
-	timeout := 10     //represents seconds
+	dialer := net.Dialer{Timeout: withDefault(pr.DialTimeout, defaultDialTimeout)}
 
-	connection, connectionError := net.DialTimeout(protocol, net.JoinHostPort(pr.Address, pr.Port), time.Duration(timeout)*time.Second)
+	connection, connectionError := dialer.DialContext(ctx, protocol, pr.hostPort())
 	if connectionError != nil {
-		return connection, errors.New("failed to establish a connection with " +
-			"pjlink device. error msg: " + connectionError.Error())
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			connectionError = ctxErr
+		}
+	}
+	if pr.Tracer != nil {
+		pr.Tracer.Trace(TraceEvent{Kind: TraceConnect, Time: time.Now(), Address: pr.hostPort(), Err: connectionError})
+	}
+	if connectionError != nil {
+		return connection, &NetworkError{Op: "dial", Err: connectionError}
 	}
 	return connection, connectionError
 }
 
+func withDefault(timeout time.Duration, fallback time.Duration) time.Duration {
+	if timeout <= 0 {
+		return fallback
+	}
+	return timeout
+}
+
+// address of the device, falls back to the PJLink port if none is set
+func (pr *PJProjector) hostPort() string {
+	port := pr.Port
+	if port == "" {
+		port = pjLinkPort
+	}
+	return net.JoinHostPort(pr.Address, port)
+}
+
 // check if this Projector uses authentication. If so return true and the given seed. Otherwise false and an empty string.
 func (pr *PJProjector) checkAuthentication(response []string) (seed string) {
-	if response[0] != "PJLINK" {
+	if len(response) < 2 || response[0] != "PJLINK" {
 		return ""
 	}
 	if response[1] == "0" {
 		return ""
-	} else if response[1] == "1" {
+	} else if response[1] == "1" && len(response) > 2 {
 		return response[2]
 	}
 
@@ -0,0 +1,27 @@
+package pjlink_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+func TestProjectorHidesPassword(t *testing.T) {
+	pr := pjlink.NewProjector("10.0.0.5", "JBMIAProjectorLink")
+
+	data, err := json.Marshal(pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, out := range []string{string(data), fmt.Sprintf("%v", pr), fmt.Sprintf("%+v", *pr), fmt.Sprintf("%#v", pr)} {
+		if strings.Contains(out, "JBMIAProjectorLink") {
+			t.Errorf("password in %s", out)
+		}
+	}
+	if !strings.Contains(string(data), `"Address":"10.0.0.5"`) {
+		t.Errorf("JSON = %s, want the address", data)
+	}
+}
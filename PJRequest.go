@@ -3,7 +3,6 @@ package pjlink
 import (
 	"crypto/md5"
 	"encoding/hex"
-	"errors"
 	"strconv"
 )
 
@@ -16,21 +15,21 @@ type PJRequest struct {
 // checks basic validity of the Request
 func (request *PJRequest) Validate() error {
 	if len(request.Command) != 4 { // 4 characters is standard command length for PJLink
-		return errors.New("Your command doesn't have character length of 4")
+		return request.invalid("Your command doesn't have character length of 4")
 	}
 
 	if len(request.Parameter) > 128 {
-		return errors.New("Parameter exceeds maximum of 128 bytes.")
+		return request.invalid("Parameter exceeds maximum of 128 bytes.")
 	}
 
 	// Could not find a parameter in PJLink Spec of length 0
 	if len(request.Parameter) == 0 {
-		return errors.New("Parameter of length 0.")
+		return request.invalid("Parameter of length 0.")
 	}
 
 	// check if Class is either 1 or 2
 	if request.Class != 1 && request.Class != 2 {
-		return errors.New("Invalid PjLink Class. Must be either 1 or 2")
+		return request.invalid("Invalid PjLink Class. Must be either 1 or 2")
 	}
 
 	return request.validateCommandParameter()
@@ -39,18 +38,75 @@ func (request *PJRequest) Validate() error {
 func (request *PJRequest) validateCommandParameter() error {
 	if request.Class == 1 {
 		if _, ok := CommandMapClass1[request.Command]; !ok {
-			return errors.New("Not a valid PjLink Class 1 Command.")
+			return request.invalid("Not a valid PjLink Class 1 Command.")
 		}
 	} else if request.Class == 2 {
 		if _, ok := CommandMapClass2[request.Command]; !ok {
-			return errors.New("Not a valid PjLink Class 2 Command.")
+			return request.invalid("Not a valid PjLink Class 2 Command.")
 		}
-		return errors.New("Class 2 not implemented yet.")
+		return request.validateClass2Parameter()
 	}
 
 	return nil
 }
 
+// checks the parameter against the values the Class 2 spec allows for the command
+func (request *PJRequest) validateClass2Parameter() error {
+	switch request.Command {
+	case "SNUM", "SVER", "IRES", "RRES", "FILT", "RLMP", "RFIL", "INST":
+		if request.Parameter != "?" {
+			return request.invalid(request.Command + " only supports the query parameter \"?\".")
+		}
+	case "INNM":
+		if len(request.Parameter) != 3 || request.Parameter[0] != '?' || !isClass2Input(request.Parameter[1:]) {
+			return request.invalid("INNM parameter must be \"?\" followed by an input, e.g. \"?31\".")
+		}
+	case "INPT":
+		if request.Parameter != "?" && !isClass2Input(request.Parameter) {
+			return request.invalid("INPT parameter must be \"?\" or an input, e.g. \"3A\".")
+		}
+	case "AVMT":
+		if !containsValue(AVMuteRequests, request.Parameter) {
+			return request.invalid("Not a valid AVMT parameter.")
+		}
+	case "SVOL", "MVOL":
+		if !containsValue(VolumeRequests, request.Parameter) {
+			return request.invalid(request.Command + " parameter must be \"0\" (down) or \"1\" (up).")
+		}
+	case "FREZ":
+		if !containsValue(FreezeRequests, request.Parameter) {
+			return request.invalid("FREZ parameter must be \"?\", \"0\" or \"1\".")
+		}
+	}
+
+	return nil
+}
+
+func (request *PJRequest) invalid(reason string) error {
+	return &RequestError{Command: request.Command, Reason: reason}
+}
+
+// checks if value is one of the raw values of a request map
+func containsValue(requests map[string]string, value string) bool {
+	for _, raw := range requests {
+		if raw == value {
+			return true
+		}
+	}
+	return false
+}
+
+// checks if raw is a Class 2 input, i.e. a source type 1-6 followed by a number 1-9 or letter A-Z
+func isClass2Input(raw string) bool {
+	if len(raw) != 2 {
+		return false
+	}
+	if raw[0] < '1' || raw[0] > '6' {
+		return false
+	}
+	return (raw[1] >= '1' && raw[1] <= '9') || (raw[1] >= 'A' && raw[1] <= 'Z')
+}
+
 // Converts to Wire Format if password or seed are empty "" assume no authentication
 func (request *PJRequest) toRaw(seed string, password string) string {
 	if seed == "" || password == "" {
@@ -89,7 +145,11 @@ var CommandMapClass1 = map[string]bool{
 	"CLSS": true,
 }
 
+// available commands Class 2, INPT, INST and AVMT take the extended Class 2 parameters
 var CommandMapClass2 = map[string]bool{
+	"INPT": true,
+	"INST": true,
+	"AVMT": true,
 	"SNUM": true,
 	"SVER": true,
 	"INNM": true,
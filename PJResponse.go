@@ -1,7 +1,6 @@
 package pjlink
 
 import (
-	"errors"
 	"strings"
 )
 
@@ -17,16 +16,20 @@ func NewPJResponse() *PJResponse {
 
 func (res *PJResponse) Parse(raw string) error {
 	// If password is wrong, response will be 'PJLINK ERRA'
-	if strings.Contains(raw, "ERRA") {
-		return errors.New("Incorrect password")
+	if strings.HasPrefix(raw, "PJLINK ERRA") {
+		return &ProjectorError{Code: "ERRA"}
 	}
 	if len(raw) == 0 {
-		return errors.New("Empty Response")
+		return &ResponseError{Reason: "Empty Response"}
 	}
 
 	tokens := strings.Split(raw, " ")
 
 	token0 := tokens[0]
+	// %<class><command>=<parameter>
+	if len(token0) < 7 || token0[0] != '%' || token0[6] != '=' {
+		return &ResponseError{Response: raw, Reason: "Malformed Response: " + raw}
+	}
 	param1 := []string{token0[7:len(token0)]}
 	paramsN := tokens[1:len(tokens)]
 	params := append(param1, paramsN...)
@@ -45,3 +48,14 @@ func (res *PJResponse) Success() (bool) {
 	}
 	return false
 }
+
+// Returns a *ProjectorError if the Projector answered with one of the PJLink error codes (ERR1-ERR4)
+func (res *PJResponse) Err() error {
+	if len(res.Response) == 0 {
+		return &ResponseError{Command: res.Command, Reason: "Empty Response"}
+	}
+	if _, ok := ErrorResponses[res.Response[0]]; ok {
+		return &ProjectorError{Command: res.Command, Code: res.Response[0]}
+	}
+	return nil
+}
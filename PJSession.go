@@ -0,0 +1,182 @@
+package pjlink
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+var errClosedByDevice = errors.New("connection closed by pjlink device")
+
+// projectors close the connection after 30 seconds without a command, stop reusing it a bit earlier
+var sessionMaxIdle = 25 * time.Second
+
+// a TCP connection to a PJLink device that already received its greeting
+type pjConn struct {
+	net.Conn
+	scanner *bufio.Scanner
+
+	seed     string    // authentication seed of the greeting, only the first command carries the digest
+	lastUsed time.Time // for the idle timeout of the projector
+	broken   bool      // the connection failed while writing or reading
+
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+
+	tracer  Tracer // receives the traffic of the connection if set
+	address string // host:port for the tracer
+}
+
+func newPJConn(connection net.Conn, readTimeout time.Duration, writeTimeout time.Duration) *pjConn {
+	scanner := bufio.NewScanner(connection)
+	scanner.Split(onCarriageReturn)
+
+	return &pjConn{
+		Conn:         connection,
+		scanner:      scanner,
+		lastUsed:     time.Now(),
+		readTimeout:  readTimeout,
+		writeTimeout: writeTimeout,
+	}
+}
+
+// sends a single request and reads the matching response line, the password is only
+// asked for if the device sent an authentication challenge
+func (conn *pjConn) exchange(ctx context.Context, request PJRequest, password func(context.Context) (string, error)) (*PJResponse, error) {
+	secret := ""
+	if conn.seed != "" {
+		var err error
+		if secret, err = password(ctx); err != nil {
+			return nil, err
+		}
+	}
+	stringCommand := request.toRaw(conn.seed, secret)
+	traced := stringCommand
+	if conn.seed != "" && secret != "" {
+		traced = redactedDigest + stringCommand[len(redactedDigest):]
+	}
+	conn.seed = "" // authenticated for the rest of the connection
+
+	//send command
+	if err := conn.write(ctx, []byte(stringCommand)); err != nil {
+		conn.trace(TraceSend, []byte(traced), err)
+		conn.broken = true
+		return nil, &NetworkError{Op: "write", Err: err}
+	}
+	conn.trace(TraceSend, []byte(traced), nil)
+	line, err := conn.readLine(ctx) //grab response line
+	if err != nil {
+		conn.trace(TraceReceive, nil, err)
+		conn.broken = true
+		return nil, &NetworkError{Op: "read", Err: err}
+	}
+	conn.trace(TraceReceive, []byte(line+"\r"), nil)
+	conn.lastUsed = time.Now()
+
+	resp := NewPJResponse()
+	err = resp.Parse(line)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// closes the TCP connection, traced as the close event
+func (conn *pjConn) Close() error {
+	err := conn.Conn.Close()
+	conn.trace(TraceClose, nil, err)
+	return err
+}
+
+func (conn *pjConn) write(ctx context.Context, data []byte) error {
+	stop, err := conn.watch(ctx, conn.writeTimeout, conn.SetWriteDeadline)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	if _, err := conn.Write(data); err != nil {
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
+}
+
+func (conn *pjConn) readLine(ctx context.Context) (string, error) {
+	stop, err := conn.watch(ctx, conn.readTimeout, conn.SetReadDeadline)
+	if err != nil {
+		return "", err
+	}
+	defer stop()
+
+	if !conn.scanner.Scan() {
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return "", ctxErr
+		}
+		if err := conn.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", errClosedByDevice
+	}
+	return conn.scanner.Text(), nil
+}
+
+// sets the deadline for the next read or write and interrupts it once ctx is done
+func (conn *pjConn) watch(ctx context.Context, timeout time.Duration, setDeadline func(time.Time) error) (stop func() bool, err error) {
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := setDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	stop = context.AfterFunc(ctx, func() {
+		setDeadline(time.Unix(1, 0)) // a deadline in the past unblocks the pending call
+	})
+	// ctx may have been done before the callback was registered
+	if ctx.Err() != nil {
+		stop()
+		return nil, ctx.Err()
+	}
+	return stop, nil
+}
+
+func (conn *pjConn) expired() bool {
+	return time.Since(conn.lastUsed) > sessionMaxIdle
+}
+
+// like ctx.Err(), but also reports a deadline that passed a moment before ctx noticed
+func contextError(ctx context.Context) error {
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return ctx.Err()
+}
+
+// split function that separates on carriage return (i.e '\r'), a trailing '\n' some devices send is dropped
+func onCarriageReturn(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start := 0
+	for start < len(data) && data[start] == '\n' {
+		start++
+	}
+	for i := start; i < len(data); i++ {
+		if data[i] == '\r' {
+			return i + 1, data[start:i], nil
+		}
+	}
+	if atEOF && len(data) > start {
+		// deliver what the device sent before closing the connection
+		return len(data), data[start:], nil
+	}
+	if atEOF {
+		return len(data), nil, nil
+	}
+	// request more data
+	return start, nil, nil
+}
@@ -0,0 +1,77 @@
+package pjlink
+
+import (
+	"context"
+)
+
+// AVMute holds the video and audio mute state as reported by AVMT
+type AVMute struct {
+	Video bool `json:"video"`
+	Audio bool `json:"audio"`
+}
+
+// decodes an AVMT query response, "1x" is video, "2x" audio and "3x" both, x being 1 for mute on and 0 for off.
+// Only one of the combined values is reported, so "11" means video mute on and audio mute off.
+func interpretAVMuteResponse(raw string) (AVMute, error) {
+	switch raw {
+	case "11":
+		return AVMute{Video: true}, nil
+	case "21":
+		return AVMute{Audio: true}, nil
+	case "31":
+		return AVMute{Video: true, Audio: true}, nil
+	case "30", "10", "20":
+		// some devices answer with the off code of the last command instead of 30
+		return AVMute{}, nil
+	}
+	return AVMute{}, &ResponseError{Command: "AVMT", Response: raw, Reason: "Invalid AV mute status: " + raw}
+}
+
+func (pr *PJProjector) GetAVMute() (AVMute, error) {
+	return pr.GetAVMuteContext(context.Background())
+}
+
+func (pr *PJProjector) GetAVMuteContext(ctx context.Context) (AVMute, error) {
+	resp, err := pr.query(ctx, pr.extendedClass(), "AVMT", AVMuteRequests["query"])
+	if err != nil {
+		return AVMute{}, err
+	}
+	mute, err := interpretAVMuteResponse(resp.Response[0])
+	return mute, pr.annotate(err, "AVMT")
+}
+
+// Blanks or restores the picture without changing the audio mute
+func (pr *PJProjector) SetVideoMute(on bool) error {
+	return pr.SetVideoMuteContext(context.Background(), on)
+}
+
+func (pr *PJProjector) SetVideoMuteContext(ctx context.Context, on bool) error {
+	if on {
+		return pr.execute(ctx, pr.extendedClass(), "AVMT", AVMuteRequests["video-mute-on"])
+	}
+	return pr.execute(ctx, pr.extendedClass(), "AVMT", AVMuteRequests["video-mute-off"])
+}
+
+// Mutes or unmutes the audio without changing the video mute
+func (pr *PJProjector) SetAudioMute(on bool) error {
+	return pr.SetAudioMuteContext(context.Background(), on)
+}
+
+func (pr *PJProjector) SetAudioMuteContext(ctx context.Context, on bool) error {
+	if on {
+		return pr.execute(ctx, pr.extendedClass(), "AVMT", AVMuteRequests["audio-mute-on"])
+	}
+	return pr.execute(ctx, pr.extendedClass(), "AVMT", AVMuteRequests["audio-mute-off"])
+}
+
+// Mutes or unmutes video and audio together
+func (pr *PJProjector) SetAVMute(on bool) error {
+	return pr.SetAVMuteContext(context.Background(), on)
+}
+
+func (pr *PJProjector) SetAVMuteContext(ctx context.Context, on bool) error {
+	if on {
+		return pr.execute(ctx, pr.extendedClass(), "AVMT", AVMuteRequests["av-mute-on"])
+	}
+	return pr.execute(ctx, pr.extendedClass(), "AVMT", AVMuteRequests["av-mute-off"])
+}
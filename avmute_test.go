@@ -0,0 +1,60 @@
+package pjlink
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAVMuteClass(t *testing.T) {
+	tests := []struct {
+		class  int
+		prefix string
+	}{
+		{0, "%1"},
+		{1, "%1"},
+		{2, "%2"},
+	}
+	for _, test := range tests {
+		t.Run(test.prefix, func(t *testing.T) {
+			pr := scriptedDevice(t, map[string]string{
+				test.prefix + "AVMT ?":  test.prefix + "AVMT=21",
+				test.prefix + "AVMT 11": test.prefix + "AVMT=OK",
+				test.prefix + "AVMT 30": test.prefix + "AVMT=ERR3",
+			})
+			pr.Class = test.class
+
+			mute, err := pr.GetAVMute()
+			if err != nil || mute != (AVMute{Audio: true}) {
+				t.Errorf("GetAVMute() = %+v, %v, want audio muted", mute, err)
+			}
+			if err := pr.SetVideoMute(true); err != nil {
+				t.Errorf("SetVideoMute(true) = %v", err)
+			}
+			if err := pr.SetAVMute(false); !errors.Is(err, ErrUnavailableTime) {
+				t.Errorf("SetAVMute(false) = %v, want ERR3", err)
+			}
+		})
+	}
+}
+
+func TestInterpretAVMuteResponse(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want AVMute
+		err  error
+	}{
+		{"11", AVMute{Video: true}, nil},
+		{"21", AVMute{Audio: true}, nil},
+		{"31", AVMute{Video: true, Audio: true}, nil},
+		{"30", AVMute{}, nil},
+		{"10", AVMute{}, nil},
+		{"20", AVMute{}, nil},
+		{"41", AVMute{}, ErrInvalidResponse},
+	}
+	for _, test := range tests {
+		got, err := interpretAVMuteResponse(test.raw)
+		if got != test.want || !errors.Is(err, test.err) {
+			t.Errorf("interpretAVMuteResponse(%s) = %+v, %v, want %+v, %v", test.raw, got, err, test.want, test.err)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package pjlink
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// starts a device without authentication that answers each request line, e.g. "%2IRES ?",
+// with the raw response of script, e.g. "%2IRES=1920x1080"
+func scriptedDevice(t *testing.T, script map[string]string) *PJProjector {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				conn.Write([]byte("PJLINK 0\r"))
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\r')
+					if err != nil {
+						return
+					}
+					line = strings.TrimSuffix(line, "\r")
+					response, ok := script[line]
+					if !ok {
+						response = line[:6] + "=ERR1"
+					}
+					conn.Write([]byte(response + "\r"))
+				}
+			}()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	pr := NewProjector(host, "")
+	pr.Port = port
+	pr.Class = 2
+	return pr
+}
+
+func TestClass2Text(t *testing.T) {
+	pr := scriptedDevice(t, map[string]string{
+		"%2SNUM ?":   "%2SNUM=SN123456789",
+		"%2SVER ?":   "%2SVER=1.02.3",
+		"%2INNM ?31": "%2INNM=HDMI 1",
+		"%2INNM ?3A": "%2INNM=ERR2",
+	})
+
+	tests := []struct {
+		name string
+		call func() (string, error)
+		want string
+		err  error
+	}{
+		{"SNUM", pr.GetSerialNumber, "SN123456789", nil},
+		{"SVER", pr.GetSoftwareVersion, "1.02.3", nil},
+		{"INNM with spaces", func() (string, error) { return pr.GetInputName("31") }, "HDMI 1", nil},
+		{"INNM out of parameter", func() (string, error) { return pr.GetInputName("3A") }, "", ErrOutOfParameter},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.call()
+			if !errors.Is(err, test.err) {
+				t.Fatalf("err = %v, want %v", err, test.err)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestResolution(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     Resolution
+		err      error
+	}{
+		{"signal", "%2IRES=1920x1080", Resolution{Width: 1920, Height: 1080}, nil},
+		{"no signal", "%2IRES=-", Resolution{}, ErrNoSignal},
+		{"unknown signal", "%2IRES=*", Resolution{}, ErrUnknownSignal},
+		{"missing x", "%2IRES=1920", Resolution{}, ErrInvalidResponse},
+		{"not a number", "%2IRES=axb", Resolution{}, ErrInvalidResponse},
+		{"unavailable", "%2IRES=ERR3", Resolution{}, ErrUnavailableTime},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := scriptedDevice(t, map[string]string{"%2IRES ?": test.response})
+			got, err := pr.GetInputResolution()
+			if !errors.Is(err, test.err) {
+				t.Fatalf("err = %v, want %v", err, test.err)
+			}
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	pr := scriptedDevice(t, map[string]string{"%2RRES ?": "%2RRES=1280x800"})
+	got, err := pr.GetRecommendedResolution()
+	if err != nil || got.String() != "1280x800" {
+		t.Errorf("RRES = %v, %v, want 1280x800", got, err)
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	tests := []struct {
+		response string
+		want     bool
+		err      error
+	}{
+		{"%2FREZ=1", true, nil},
+		{"%2FREZ=0", false, nil},
+		{"%2FREZ=2", false, ErrInvalidResponse},
+		{"%2FREZ=ERR3", false, ErrUnavailableTime},
+	}
+	for _, test := range tests {
+		t.Run(test.response, func(t *testing.T) {
+			pr := scriptedDevice(t, map[string]string{"%2FREZ ?": test.response})
+			got, err := pr.GetFreeze()
+			if !errors.Is(err, test.err) {
+				t.Fatalf("err = %v, want %v", err, test.err)
+			}
+			if got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+
+	pr := scriptedDevice(t, map[string]string{"%2FREZ 1": "%2FREZ=OK", "%2FREZ 0": "%2FREZ=ERR3"})
+	if err := pr.SetFreeze(true); err != nil {
+		t.Errorf("SetFreeze(true) = %v", err)
+	}
+	if err := pr.SetFreeze(false); !errors.Is(err, ErrUnavailableTime) {
+		t.Errorf("SetFreeze(false) = %v, want ERR3", err)
+	}
+}
+
+func TestReplacementModels(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		response string
+		want     []string
+	}{
+		{"lamp models", "RLMP", "%2RLMP=ELPLP67 ELPLP68", []string{"ELPLP67", "ELPLP68"}},
+		{"single lamp model", "RLMP", "%2RLMP=VT85LP", []string{"VT85LP"}},
+		{"no lamp models", "RLMP", "%2RLMP=", []string{}},
+		{"filter models", "RFIL", "%2RFIL=ELPAF32 ELPAF36", []string{"ELPAF32", "ELPAF36"}},
+		{"no filter models", "RFIL", "%2RFIL=", []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := scriptedDevice(t, map[string]string{"%2" + test.command + " ?": test.response})
+			call := pr.GetLampReplacementModels
+			if test.command == "RFIL" {
+				call = pr.GetFilterReplacementModels
+			}
+			got, err := call()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFilterUsage(t *testing.T) {
+	pr := scriptedDevice(t, map[string]string{"%2FILT ?": "%2FILT=1234"})
+	if hours, err := pr.GetFilterUsage(); err != nil || hours != 1234 {
+		t.Errorf("FILT = %d, %v, want 1234", hours, err)
+	}
+
+	pr = scriptedDevice(t, map[string]string{"%2FILT ?": "%2FILT=many"})
+	if _, err := pr.GetFilterUsage(); !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("FILT = %v, want invalid response", err)
+	}
+}
+
+func TestVolume(t *testing.T) {
+	pr := scriptedDevice(t, map[string]string{
+		"%2SVOL 1": "%2SVOL=OK",
+		"%2SVOL 0": "%2SVOL=OK",
+		"%2MVOL 1": "%2MVOL=OK",
+		"%2MVOL 0": "%2MVOL=ERR4",
+	})
+	for name, call := range map[string]func() error{
+		"SVOL up":   pr.SpeakerVolumeUp,
+		"SVOL down": pr.SpeakerVolumeDown,
+		"MVOL up":   pr.MicrophoneVolumeUp,
+	} {
+		if err := call(); err != nil {
+			t.Errorf("%s = %v", name, err)
+		}
+	}
+	if err := pr.MicrophoneVolumeDown(); !errors.Is(err, ErrDeviceFailure) {
+		t.Errorf("MVOL down = %v, want ERR4", err)
+	}
+}
+
+func TestValidateClass2Parameter(t *testing.T) {
+	tests := []struct {
+		command   string
+		parameter string
+		valid     bool
+	}{
+		{"SVOL", "0", true},
+		{"SVOL", "1", true},
+		{"SVOL", "?", false},
+		{"SVOL", "2", false},
+		{"SVOL", "10", false},
+		{"MVOL", "1", true},
+		{"MVOL", "-1", false},
+		{"MVOL", "up", false},
+		{"INNM", "?31", true},
+		{"INNM", "?3A", true},
+		{"INNM", "?", false},
+		{"INNM", "31", false},
+		{"INNM", "?71", false},
+		{"INNM", "?30", false},
+		{"INNM", "?3a", false},
+		{"INNM", "?311", false},
+		{"FREZ", "?", true},
+		{"FREZ", "2", false},
+		{"IRES", "?", true},
+		{"IRES", "1", false},
+		{"RLMP", "?", true},
+		{"RFIL", "0", false},
+		{"INPT", "3A", true},
+		{"INPT", "7A", false},
+	}
+	for _, test := range tests {
+		t.Run(test.command+" "+test.parameter, func(t *testing.T) {
+			request := PJRequest{Class: 2, Command: test.command, Parameter: test.parameter}
+			err := request.Validate()
+			if test.valid && err != nil {
+				t.Errorf("Validate() = %v, want nil", err)
+			}
+			if !test.valid && !errors.Is(err, ErrInvalidRequest) {
+				t.Errorf("Validate() = %v, want invalid request", err)
+			}
+		})
+	}
+}
+
+func TestInvalidClass2RequestIsNotSent(t *testing.T) {
+	pr := scriptedDevice(t, map[string]string{})
+	if _, err := pr.GetInputName("9Z"); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("GetInputName(9Z) = %v, want invalid request", err)
+	}
+}
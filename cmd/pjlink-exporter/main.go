@@ -0,0 +1,55 @@
+// Command pjlink-exporter serves Prometheus metrics of PJLink projectors.
+//
+//	pjlink-exporter -listen :9352 -inventory projectors.yaml
+//
+// The projectors to scrape are named in an inventory file, see package inventory.
+// Their tags select a subset with -group.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/LightInstruments/pjlink/exporter"
+	"github.com/LightInstruments/pjlink/inventory"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func main() {
+	listen := flag.String("listen", ":9352", "address to serve metrics on")
+	inventoryPath := flag.String("inventory", "projectors.yaml", "inventory file of the projectors to scrape, YAML or TOML")
+	group := flag.String("group", "", "only scrape the projectors with this tag")
+	timeout := flag.Duration("timeout", 10*time.Second, "limit of scraping a single projector")
+	scrapeTimeout := flag.Duration("scrape-timeout", 9*time.Second, "limit of a whole scrape, keep it below the scrape_timeout of Prometheus")
+	parallelism := flag.Int("parallelism", 8, "projectors scraped at once")
+	flag.Parse()
+
+	inv, err := inventory.Load(*inventoryPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	names := inv.Names()
+	if *group != "" {
+		if names, err = inv.Select(nil, []string{*group}); err != nil {
+			log.Fatal(err)
+		}
+	}
+	fleet, err := inv.Fleet(names...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fleet.Timeout = *timeout
+	fleet.Parallelism = *parallelism
+
+	registry := prometheus.NewRegistry()
+	collector := exporter.New(fleet)
+	collector.Timeout = *scrapeTimeout
+	registry.MustRegister(collector)
+
+	http.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	log.Printf("serving metrics of %d projectors on %s/metrics", len(fleet.Names()), *listen)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
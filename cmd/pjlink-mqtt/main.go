@@ -0,0 +1,75 @@
+// Command pjlink-mqtt publishes the state of PJLink projectors to an MQTT broker and
+// forwards set commands from it, see package mqttbridge for the topics.
+//
+//	pjlink-mqtt -broker tcp://localhost:1883 -inventory projectors.yaml
+//
+// The projectors are named in an inventory file, see package inventory.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/inventory"
+	"github.com/LightInstruments/pjlink/mqttbridge"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+func main() {
+	broker := flag.String("broker", "tcp://localhost:1883", "URL of the MQTT broker")
+	clientID := flag.String("client-id", "pjlink-mqtt", "MQTT client id")
+	prefix := flag.String("prefix", "pjlink", "first level of all topics")
+	inventoryPath := flag.String("inventory", "projectors.yaml", "inventory file of named projectors, YAML or TOML")
+	interval := flag.Duration("interval", 30*time.Second, "time between polls of a projector")
+	flag.Parse()
+
+	monitor, err := loadMonitor(*inventoryPath, *interval)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := mqtt.NewClient(mqtt.NewClientOptions().
+		AddBroker(*broker).
+		SetClientID(*clientID).
+		SetAutoReconnect(true))
+	if token := client.Connect(); token.Wait() && token.Error() != nil {
+		log.Fatalf("failed to connect to %s: %v", *broker, token.Error())
+	}
+	defer client.Disconnect(250)
+
+	bridge := mqttbridge.New(client, monitor)
+	bridge.Prefix = *prefix
+	bridge.Logger = log.Default()
+
+	go monitor.Run(ctx)
+	log.Printf("bridging %d projectors to %s", len(monitor.Names()), *broker)
+	if err := bridge.Run(ctx); err != nil && ctx.Err() == nil {
+		log.Fatal(err)
+	}
+}
+
+func loadMonitor(path string, interval time.Duration) (*pjlink.Monitor, error) {
+	inv, err := inventory.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor := pjlink.NewMonitor(interval)
+	for _, name := range inv.Names() {
+		pr, err := inv.Projector(name)
+		if err != nil {
+			return nil, err
+		}
+		monitor.Add(name, pr)
+	}
+	return monitor, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+const pjlinkPort = "4352"
+
+// returned for addresses that are neither in the config nor in the inventory. Serving any address would
+// let clients point the server at a host of their own, which could then collect digests of the
+// password or use the server as a relay.
+var errUnknownProjector = errors.New("unknown projector")
+
+// config of the server, read from a JSON file, only the listed projectors are served:
+//
+//	{
+//	  "password": "default password",
+//	  "projectors": {
+//	    "10.0.0.5": {"password": "secret", "class": 2},
+//	    "10.0.0.6": {"port": "14352"}
+//	  }
+//	}
+type config struct {
+	Password   string                   `json:"password"` // used for listed projectors without their own password
+	Timeout    string                   `json:"timeout"`  // per request, e.g. "15s"
+	Projectors map[string]projectorConf `json:"projectors"`
+}
+
+type projectorConf struct {
+	Port     string `json:"port"`
+	Password string `json:"password"`
+	Class    int    `json:"class"`
+}
+
+func loadConfig(path string) (config, error) {
+	var conf config
+	if path == "" {
+		return conf, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return conf, err
+	}
+	err = json.Unmarshal(data, &conf)
+	return conf, err
+}
+
+func (conf config) timeout() (time.Duration, error) {
+	if conf.Timeout == "" {
+		return 15 * time.Second, nil
+	}
+	return time.ParseDuration(conf.Timeout)
+}
+
+// builds a client for addr with the settings of its entry. addr is an entry or the host of an
+// entry with its port, e.g. "10.0.0.6:14352" for the entry "10.0.0.6" with port 14352.
+func (conf config) projector(addr string) (*pjlink.PJProjector, error) {
+	entry, ok := conf.Projectors[addr]
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		host, port = addr, entry.Port
+	}
+	if !ok {
+		entry, ok = conf.Projectors[host]
+		// other ports of a listed host are not served, its password is only meant for the projector
+		ok = ok && port == withDefault(entry.Port, pjlinkPort)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w %s, add it to the config or the inventory", errUnknownProjector, addr)
+	}
+
+	password := entry.Password
+	if password == "" {
+		password = conf.Password
+	}
+	pr := pjlink.NewProjector(host, password)
+	pr.Port = withDefault(port, pjlinkPort)
+	pr.Class = entry.Class
+	return pr, nil
+}
+
+func withDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
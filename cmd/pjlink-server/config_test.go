@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfigProjector(t *testing.T) {
+	conf := config{
+		Password: "default",
+		Projectors: map[string]projectorConf{
+			"10.0.0.5":       {Password: "secret", Class: 2},
+			"10.0.0.6":       {Port: "14352"},
+			"10.0.0.7:24352": {},
+		},
+	}
+
+	tests := []struct {
+		addr     string
+		hostPort string
+		password string
+		class    int
+	}{
+		{"10.0.0.5", "10.0.0.5:4352", "secret", 2},
+		{"10.0.0.5:4352", "10.0.0.5:4352", "secret", 2},
+		{"10.0.0.6", "10.0.0.6:14352", "default", 0},
+		{"10.0.0.6:14352", "10.0.0.6:14352", "default", 0},
+		{"10.0.0.7:24352", "10.0.0.7:24352", "default", 0},
+	}
+	for _, test := range tests {
+		pr, err := conf.projector(test.addr)
+		if err != nil {
+			t.Errorf("projector(%s) = %v", test.addr, err)
+			continue
+		}
+		if got := pr.Address + ":" + pr.Port; got != test.hostPort || pr.Password != test.password || pr.Class != test.class {
+			t.Errorf("projector(%s) = %s %q Class %d, want %s %q Class %d", test.addr, got, pr.Password, pr.Class, test.hostPort, test.password, test.class)
+		}
+	}
+
+	// the default password must never be sent to a host the caller picked
+	for _, addr := range []string{"10.0.0.8", "10.0.0.8:4352", "10.0.0.5:4353", "10.0.0.6:4352", "10.0.0.7", "attacker.example:4352"} {
+		if _, err := conf.projector(addr); !errors.Is(err, errUnknownProjector) {
+			t.Errorf("projector(%s) = %v, want unknown projector", addr, err)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+type server struct {
+	projector func(addr string) (*pjlink.PJProjector, error)
+	timeout   time.Duration
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projectors/{addr}/power", s.getPower)
+	mux.HandleFunc("PUT /projectors/{addr}/power", s.putPower)
+	mux.HandleFunc("GET /projectors/{addr}/input", s.getInput)
+	mux.HandleFunc("PUT /projectors/{addr}/input", s.putInput)
+	mux.HandleFunc("GET /projectors/{addr}/inputs", s.getInputs)
+	mux.HandleFunc("GET /projectors/{addr}/av-mute", s.getAVMute)
+	mux.HandleFunc("PUT /projectors/{addr}/av-mute", s.putAVMute)
+	mux.HandleFunc("GET /projectors/{addr}/error-status", s.getErrorStatus)
+	mux.HandleFunc("GET /projectors/{addr}/lamps", s.getLamps)
+	mux.HandleFunc("GET /projectors/{addr}/info", s.getInfo)
+	mux.HandleFunc("POST /projectors/{addr}/raw", s.postRaw)
+	return mux
+}
+
+type powerBody struct {
+	State string `json:"state"`
+}
+
+type inputBody struct {
+	Input string `json:"input"`
+	Raw   string `json:"raw"`
+}
+
+// fields left out of a PUT keep their current state
+type avMuteBody struct {
+	Video *bool `json:"video"`
+	Audio *bool `json:"audio"`
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"` // ERR1-ERR4 or ERRA if the projector answered with an error
+}
+
+func (s *server) getPower(w http.ResponseWriter, r *http.Request) {
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	state, err := pr.PowerContext(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, powerBody{State: state.String()})
+}
+
+func (s *server) putPower(w http.ResponseWriter, r *http.Request) {
+	var body powerBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	switch body.State {
+	case pjlink.PowerOn.String():
+		err = pr.TurnOnContext(ctx)
+	case pjlink.PowerOff.String():
+		err = pr.TurnOffContext(ctx)
+	default:
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: `state must be "on" or "off"`})
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) getInput(w http.ResponseWriter, r *http.Request) {
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	in, err := pr.GetInputContext(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, inputBody{Input: in.String(), Raw: in.Raw()})
+}
+
+func (s *server) putInput(w http.ResponseWriter, r *http.Request) {
+	var body inputBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	var in pjlink.Input
+	var err error
+	switch {
+	case body.Raw != "":
+		in, err = pjlink.ParseInput(body.Raw)
+	default:
+		in, err = pjlink.ParseHumanInput(body.Input)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	if err := pr.SetInputContext(ctx, in); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) getInputs(w http.ResponseWriter, r *http.Request) {
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	inputs, err := pr.InputsContext(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body := make([]inputBody, 0, len(inputs))
+	for _, in := range inputs {
+		body = append(body, inputBody{Input: in.String(), Raw: in.Raw()})
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *server) getAVMute(w http.ResponseWriter, r *http.Request) {
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	mute, err := pr.GetAVMuteContext(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mute)
+}
+
+func (s *server) putAVMute(w http.ResponseWriter, r *http.Request) {
+	var body avMuteBody
+	if !readJSON(w, r, &body) {
+		return
+	}
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	switch {
+	case body.Video != nil && body.Audio != nil && *body.Video == *body.Audio:
+		err = pr.SetAVMuteContext(ctx, *body.Video)
+	default:
+		if body.Video != nil {
+			err = pr.SetVideoMuteContext(ctx, *body.Video)
+		}
+		if err == nil && body.Audio != nil {
+			err = pr.SetAudioMuteContext(ctx, *body.Audio)
+		}
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) getErrorStatus(w http.ResponseWriter, r *http.Request) {
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	status, err := pr.ErrorStatusContext(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *server) getLamps(w http.ResponseWriter, r *http.Request) {
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	lamps, err := pr.LampsContext(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, lamps)
+}
+
+func (s *server) getInfo(w http.ResponseWriter, r *http.Request) {
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	id, err := pr.IdentifyContext(ctx)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, id)
+}
+
+// sends the request as is, the projector's answer is returned even if it is an error code
+func (s *server) postRaw(w http.ResponseWriter, r *http.Request) {
+	var request pjlink.PJRequest
+	if !readJSON(w, r, &request) {
+		return
+	}
+	if request.Class == 0 {
+		request.Class = 1
+	}
+	ctx, pr, cancel, err := s.open(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer cancel()
+
+	resp, err := pr.SendRequestContext(ctx, request)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	status := http.StatusOK
+	if err := resp.Err(); err != nil {
+		status = httpStatus(err)
+	}
+	writeJSON(w, status, resp)
+}
+
+// the projector named in the path with a context bounded by the request timeout
+func (s *server) open(r *http.Request) (context.Context, *pjlink.PJProjector, context.CancelFunc, error) {
+	pr, err := s.projector(r.PathValue("addr"))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	return ctx, pr, cancel, nil
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: "invalid body: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	body := errorBody{Error: err.Error()}
+	var projectorErr *pjlink.ProjectorError
+	if errors.As(err, &projectorErr) {
+		body.Code = projectorErr.Code
+	}
+	status := httpStatus(err)
+	if status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "5")
+	}
+	writeJSON(w, status, body)
+}
+
+// maps errors of the library to the HTTP status of the response:
+//
+//	ERR1 undefined command      501 Not Implemented
+//	ERR2 out of parameter       400 Bad Request
+//	ERR3 unavailable time       503 Service Unavailable
+//	ERR4 projector failure      502 Bad Gateway
+//	ERRA authentication failed  403 Forbidden
+//	network errors and timeouts 504 Gateway Timeout
+//	projectors not configured   404 Not Found
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, errUnknownProjector):
+		return http.StatusNotFound
+	case errors.Is(err, pjlink.ErrInvalidRequest), errors.Is(err, pjlink.ErrOutOfParameter):
+		return http.StatusBadRequest
+	case errors.Is(err, pjlink.ErrUndefinedCommand):
+		return http.StatusNotImplemented
+	case errors.Is(err, pjlink.ErrUnavailableTime):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, pjlink.ErrAuthentication):
+		return http.StatusForbidden
+	case errors.Is(err, pjlink.ErrNetwork), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusBadGateway
+}
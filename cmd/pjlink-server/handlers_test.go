@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+// serves hall-a from an emulated projector, wrong-password with a bad password and gone from a
+// closed one
+func startServer(t *testing.T) (*pjlinktest.Server, *httptest.Server) {
+	t.Helper()
+	device := pjlinktest.NewServer(pjlinktest.Profile{Password: "secret", Inputs: []string{"11", "31"}})
+	t.Cleanup(func() { device.Close() })
+	gone := pjlinktest.NewServer(pjlinktest.Profile{})
+	gone.Close()
+
+	projector := func(addr string) (*pjlink.PJProjector, error) {
+		switch addr {
+		case "hall-a":
+			return device.Projector(), nil
+		case "wrong-password":
+			pr := device.Projector()
+			pr.Password = "wrong"
+			return pr, nil
+		case "gone":
+			return gone.Projector(), nil
+		}
+		return nil, errUnknownProjector
+	}
+	srv := httptest.NewServer((&server{projector: projector, timeout: 2 * time.Second}).routes())
+	t.Cleanup(srv.Close)
+	return device, srv
+}
+
+func request(t *testing.T, srv *httptest.Server, method string, path string, body string) (*http.Response, errorBody) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var errBody errorBody
+	json.NewDecoder(resp.Body).Decode(&errBody)
+	return resp, errBody
+}
+
+func TestErrorStatuses(t *testing.T) {
+	device, srv := startServer(t)
+
+	tests := []struct {
+		name   string
+		setup  func()
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{"ok", nil, "GET", "/projectors/hall-a/power", "", http.StatusOK, ""},
+		{"ERR1", nil, "POST", "/projectors/hall-a/raw", `{"class":2,"command":"SNUM","parameter":"?"}`, http.StatusNotImplemented, ""},
+		{"ERR2", nil, "POST", "/projectors/hall-a/raw", `{"command":"POWR","parameter":"7"}`, http.StatusBadRequest, ""},
+		{"ERR3", nil, "PUT", "/projectors/hall-a/input", `{"input":"digital1"}`, http.StatusServiceUnavailable, "ERR3"},
+		{"ERR4", func() { device.SetFailure(true) }, "GET", "/projectors/hall-a/power", "", http.StatusBadGateway, "ERR4"},
+		{"ERRA", nil, "GET", "/projectors/wrong-password/power", "", http.StatusForbidden, "ERRA"},
+		{"unreachable", nil, "GET", "/projectors/gone/power", "", http.StatusGatewayTimeout, ""},
+		{"not listed", nil, "GET", "/projectors/10.0.0.99/power", "", http.StatusNotFound, ""},
+		{"invalid body", nil, "PUT", "/projectors/hall-a/power", `{"state":"sideways"}`, http.StatusBadRequest, ""},
+		{"invalid input", nil, "PUT", "/projectors/hall-a/input", `{"raw":"9"}`, http.StatusBadRequest, ""},
+		{"input not available", nil, "PUT", "/projectors/hall-a/input", `{"raw":"21"}`, http.StatusBadRequest, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.setup != nil {
+				test.setup()
+				defer device.SetFailure(false)
+			}
+			resp, body := request(t, srv, test.method, test.path, test.body)
+			if resp.StatusCode != test.status {
+				t.Errorf("status = %d, want %d (%s)", resp.StatusCode, test.status, body.Error)
+			}
+			if body.Code != test.code {
+				t.Errorf("code = %q, want %q (%s)", body.Code, test.code, body.Error)
+			}
+		})
+	}
+
+	// ERR3 tells the client when to try again
+	resp, _ := request(t, srv, "PUT", "/projectors/hall-a/input", `{"input":"digital1"}`)
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("no Retry-After with ERR3")
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	device, srv := startServer(t)
+	device.SetPower(pjlink.PowerOn)
+
+	if resp, body := request(t, srv, "PUT", "/projectors/hall-a/input", `{"input":"digital1"}`); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("PUT input = %d %s", resp.StatusCode, body.Error)
+	}
+	if device.Input() != "31" {
+		t.Errorf("input = %s, want 31", device.Input())
+	}
+
+	resp, err := srv.Client().Get(srv.URL + "/projectors/hall-a/info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var id pjlink.Identity
+	if err := json.NewDecoder(resp.Body).Decode(&id); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET info = %d, %v", resp.StatusCode, err)
+	}
+	if id.Name != "pjlinktest" || id.Class != 1 {
+		t.Errorf("info = %+v, want pjlinktest Class 1", id)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := map[error]int{
+		pjlink.ErrUndefinedCommand:                               http.StatusNotImplemented,
+		pjlink.ErrOutOfParameter:                                 http.StatusBadRequest,
+		pjlink.ErrUnavailableTime:                                http.StatusServiceUnavailable,
+		pjlink.ErrDeviceFailure:                                  http.StatusBadGateway,
+		pjlink.ErrAuthentication:                                 http.StatusForbidden,
+		pjlink.ErrNetwork:                                        http.StatusGatewayTimeout,
+		context.DeadlineExceeded:                                 http.StatusGatewayTimeout,
+		&pjlink.RequestError{Command: "POWR", Reason: "invalid"}: http.StatusBadRequest,
+		fmt.Errorf("10.0.0.99: %w", errUnknownProjector):         http.StatusNotFound,
+		fmt.Errorf("INPT: %w", pjlink.ErrOutOfParameter):         http.StatusBadRequest,
+	}
+	for err, want := range tests {
+		if got := httpStatus(err); got != want {
+			t.Errorf("httpStatus(%v) = %d, want %d", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Command pjlink-server exposes PJLink projectors over HTTP/JSON.
+//
+//	pjlink-server -listen :8080 -config projectors.json
+//	curl localhost:8080/projectors/10.0.0.5/power
+//
+// With -inventory projectors are also addressed by their name in the inventory:
+//
+//	pjlink-server -inventory projectors.yaml
+//	curl localhost:8080/projectors/room-101/power
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/inventory"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to serve HTTP on")
+	configPath := flag.String("config", "", "JSON file with the projectors to serve and their passwords")
+	inventoryPath := flag.String("inventory", "", "inventory file of named projectors, YAML or TOML")
+	flag.Parse()
+
+	conf, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("failed to load config %s: %v", *configPath, err)
+	}
+	timeout, err := conf.timeout()
+	if err != nil {
+		log.Fatalf("invalid timeout in %s: %v", *configPath, err)
+	}
+
+	var inv *inventory.Inventory
+	if *inventoryPath != "" {
+		if inv, err = inventory.Load(*inventoryPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	projector := func(addr string) (*pjlink.PJProjector, error) {
+		if inv != nil {
+			if _, ok := inv.Projectors[addr]; ok {
+				return inv.Projector(addr)
+			}
+		}
+		return conf.projector(addr)
+	}
+
+	srv := &server{projector: projector, timeout: timeout}
+	log.Printf("serving on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, srv.routes()))
+}
@@ -0,0 +1,65 @@
+// Command pjlink-sim pretends to be a PJLink projector described by a profile file.
+//
+//	pjlink-sim -profile profiles/example.yaml -listen :4352
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+func main() {
+	profilePath := flag.String("profile", "", "YAML or JSON profile of the projector model (default: generic Class 1 projector)")
+	listen := flag.String("listen", ":4352", "address to listen on")
+	password := flag.String("password", "", "PJLink password, overrides the profile")
+	mac := flag.String("mac", "", "MAC address reported by LKUP and ACKN, overrides the profile (default: random)")
+	quiet := flag.Bool("quiet", false, "don't log commands")
+	flag.Parse()
+
+	var profile pjlinktest.Profile
+	if *profilePath != "" {
+		var err error
+		profile, err = loadProfile(*profilePath)
+		if err != nil {
+			log.Fatalf("failed to load profile %s: %v", *profilePath, err)
+		}
+	}
+	if *password != "" {
+		profile.Password = *password
+	}
+	if *mac != "" {
+		if err := validateMAC(*mac); err != nil {
+			log.Fatal(err)
+		}
+		profile.MAC = *mac
+	}
+	if profile.MAC == "" {
+		profile.MAC = randomMAC()
+	}
+
+	srv := pjlinktest.NewUnstartedServer(profile)
+	if !*quiet {
+		srv.Logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+	if err := srv.Listen(*listen); err != nil {
+		log.Fatalf("failed to listen on %s: %v", *listen, err)
+	}
+	if profile.Class >= 2 {
+		// Class 2 projectors answer searches on the same port over UDP
+		if err := srv.ListenSearch(*listen); err != nil {
+			log.Fatalf("failed to listen for searches on %s: %v", *listen, err)
+		}
+	}
+	log.Printf("simulating projector %s on %s", profile.MAC, srv.Addr())
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	<-interrupt
+
+	srv.Close()
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+	"gopkg.in/yaml.v3"
+)
+
+// profileFile is the YAML/JSON description of a projector model, keys follow the PJLink commands
+type profileFile struct {
+	Name         string   `yaml:"NAME"`
+	Manufacturer string   `yaml:"INF1"`
+	Model        string   `yaml:"INF2"`
+	Info         string   `yaml:"INFO"`
+	Class        int      `yaml:"CLSS"`
+	Inputs       []string `yaml:"INST"`
+
+	Password  string `yaml:"password"`
+	MAC       string `yaml:"mac"`       // reported by LKUP and ACKN, random if not set
+	Lamps     int    `yaml:"lamps"`     // 1 to 8, 1 if not set
+	LampHours int    `yaml:"lampHours"` // up to 99999
+	WarmUp    string `yaml:"warmUp"`    // e.g. "30s"
+	CoolDown  string `yaml:"coolDown"`  // e.g. "1m"
+
+	Class2 *class2File `yaml:"class2"`
+}
+
+// optional Class 2 features, only used with CLSS 2
+type class2File struct {
+	SerialNumber    string            `yaml:"SNUM"`
+	SoftwareVersion string            `yaml:"SVER"`
+	InputNames      map[string]string `yaml:"INNM"`
+	Resolution      string            `yaml:"RRES"`
+	LampModels      []string          `yaml:"RLMP"`
+	FilterModels    []string          `yaml:"RFIL"`
+}
+
+// reads a profile, JSON is accepted as well since it is valid YAML
+func loadProfile(path string) (pjlinktest.Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return pjlinktest.Profile{}, err
+	}
+
+	var file profileFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return pjlinktest.Profile{}, err
+	}
+	return file.toProfile()
+}
+
+func (file profileFile) toProfile() (pjlinktest.Profile, error) {
+	profile := pjlinktest.Profile{
+		Name:         file.Name,
+		Manufacturer: file.Manufacturer,
+		Model:        file.Model,
+		Info:         file.Info,
+		Class:        file.Class,
+		Password:     file.Password,
+		MAC:          file.MAC,
+		Inputs:       file.Inputs,
+		Lamps:        file.Lamps,
+		LampHours:    file.LampHours,
+	}
+
+	if file.Class != 0 && file.Class != 1 && file.Class != 2 {
+		return profile, errors.New("CLSS must be 1 or 2, not " + strconv.Itoa(file.Class))
+	}
+	if file.MAC != "" {
+		if err := validateMAC(file.MAC); err != nil {
+			return profile, err
+		}
+	}
+
+	// LAMP reports at most 8 lamps with 5 digits of hours each
+	if file.Lamps < 0 || file.Lamps > 8 {
+		return profile, errors.New("lamps must be 1 to 8, not " + strconv.Itoa(file.Lamps))
+	}
+	if file.LampHours < 0 || file.LampHours > 99999 {
+		return profile, errors.New("lampHours must be 0 to 99999, not " + strconv.Itoa(file.LampHours))
+	}
+
+	for _, input := range file.Inputs {
+		if _, err := pjlink.ParseInput(input); err != nil {
+			return profile, err
+		}
+	}
+
+	var err error
+	if profile.WarmUp, err = parseDuration(file.WarmUp); err != nil {
+		return profile, err
+	}
+	if profile.CoolDown, err = parseDuration(file.CoolDown); err != nil {
+		return profile, err
+	}
+
+	if file.Class2 != nil {
+		profile.SerialNumber = file.Class2.SerialNumber
+		profile.SoftwareVersion = file.Class2.SoftwareVersion
+		profile.InputNames = file.Class2.InputNames
+		profile.Resolution = file.Class2.Resolution
+		profile.LampModels = file.Class2.LampModels
+		profile.FilterModels = file.Class2.FilterModels
+	}
+
+	return profile, nil
+}
+
+func parseDuration(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+func validateMAC(mac string) error {
+	if hw, err := net.ParseMAC(mac); err != nil || len(hw) != 6 {
+		return errors.New("mac must be a MAC address like 00:00:5e:00:53:01, not " + mac)
+	}
+	return nil
+}
+
+// a random locally administered MAC address, so simulators on one network can be told apart
+func randomMAC() string {
+	mac := make(net.HardwareAddr, 6)
+	rand.Read(mac)
+	mac[0] = mac[0]&0xfc | 0x02
+	return mac.String()
+}
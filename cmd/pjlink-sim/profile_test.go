@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+func TestLoadProfile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		data string
+		want *pjlinktest.Profile // nil if loading must fail
+	}{
+		{
+			"minimal.yaml", "NAME: hall\n",
+			&pjlinktest.Profile{Name: "hall"},
+		},
+		{
+			"full.yaml", `
+NAME: Room 101
+INF1: EPSON
+INF2: EB-L1100U
+INFO: Laser projector
+CLSS: 2
+INST: ["11", "31"]
+password: secret
+mac: 02:00:5E:00:53:65
+lamps: 8
+lampHours: 99999
+warmUp: 20s
+coolDown: 1m
+class2:
+  SNUM: X5Q1234567
+  SVER: "1.04"
+  RRES: 1920x1200
+  RLMP: [ELPLP95]
+  RFIL: [ELPAF60]
+  INNM: {"31": HDMI 1}
+`,
+			&pjlinktest.Profile{
+				Name: "Room 101", Manufacturer: "EPSON", Model: "EB-L1100U", Info: "Laser projector",
+				Class: 2, Inputs: []string{"11", "31"}, Password: "secret", MAC: "02:00:5E:00:53:65",
+				Lamps: 8, LampHours: 99999, WarmUp: 20 * time.Second, CoolDown: time.Minute,
+				SerialNumber: "X5Q1234567", SoftwareVersion: "1.04", Resolution: "1920x1200",
+				LampModels: []string{"ELPLP95"}, FilterModels: []string{"ELPAF60"},
+				InputNames: map[string]string{"31": "HDMI 1"},
+			},
+		},
+		{
+			"profile.json", `{"NAME": "hall", "CLSS": 1, "INST": ["11"], "lamps": 2, "warmUp": "5s"}`,
+			&pjlinktest.Profile{Name: "hall", Class: 1, Inputs: []string{"11"}, Lamps: 2, WarmUp: 5 * time.Second},
+		},
+		{"class.yaml", "CLSS: 3\n", nil},
+		{"mac.yaml", "mac: 00:1a:2b\n", nil},
+		{"input.yaml", "INST: [\"11\", \"99\"]\n", nil},
+		{"lamps.yaml", "lamps: 9\n", nil},
+		{"negative-lamps.yaml", "lamps: -1\n", nil},
+		{"lamp-hours.yaml", "lampHours: 100000\n", nil},
+		{"warm-up.yaml", "warmUp: soon\n", nil},
+		{"syntax.yaml", "INST: [\n", nil},
+	}
+	for _, test := range tests {
+		path := filepath.Join(dir, test.name)
+		if err := os.WriteFile(path, []byte(test.data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		profile, err := loadProfile(path)
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("loadProfile(%s) = %+v, want an error", test.name, profile)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("loadProfile(%s): %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(profile, *test.want) {
+			t.Errorf("loadProfile(%s) = %+v, want %+v", test.name, profile, *test.want)
+		}
+	}
+
+	if _, err := loadProfile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("loadProfile() of a missing file succeeded")
+	}
+}
+
+func TestExampleProfile(t *testing.T) {
+	profile, err := loadProfile(filepath.Join("profiles", "example.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Class != 2 || profile.Lamps != 1 || len(profile.Inputs) != 8 || profile.InputNames["3A"] != "Screen Mirroring" {
+		t.Errorf("example profile = %+v", profile)
+	}
+}
+
+func TestRandomMAC(t *testing.T) {
+	mac := randomMAC()
+	if err := validateMAC(mac); err != nil {
+		t.Fatal(err)
+	}
+	if first := mac[:2]; first[1] != '2' && first[1] != '6' && first[1] != 'a' && first[1] != 'e' {
+		t.Errorf("randomMAC() = %s, want a locally administered unicast address", mac)
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/spf13/cobra"
+)
+
+// errorsCmd represents the errors command
+var errorsCmd = &cobra.Command{
+	Use:   "errors",
+	Short: "Display the error status of fan, lamp, temperature, cover, filter and other",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			status, err := proj.ErrorStatusContext(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+			lines := make([]string, 0, len(pjlink.ErrorStatusComponents))
+			for _, component := range pjlink.ErrorStatusComponents {
+				level, _ := status.Level(component)
+				lines = append(lines, fmt.Sprintf("%s: %s", component, level))
+			}
+			return status, strings.Join(lines, "\n"), nil
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(errorsCmd)
+}
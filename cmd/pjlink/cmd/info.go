@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/spf13/cobra"
+)
+
+// a line of info, in the order shown
+type infoProperty struct {
+	name  string
+	value string
+}
+
+// infoCmd represents the info command
+var infoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Display name, manufacturer, model and class of the Projector",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			id, err := proj.IdentifyContext(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+			info := []infoProperty{
+				{"name", id.Name},
+				{"manufacturer", id.Manufacturer},
+				{"model", id.Model},
+				{"version", id.Info},
+				{"class", strconv.Itoa(id.Class)},
+			}
+			if proj.Class >= 2 {
+				serial, err := proj.GetSerialNumberContext(ctx)
+				if err != nil {
+					return nil, "", err
+				}
+				software, err := proj.GetSoftwareVersionContext(ctx)
+				if err != nil {
+					return nil, "", err
+				}
+				info = append(info, infoProperty{"serial-number", serial}, infoProperty{"software-version", software})
+			}
+
+			values := make(map[string]string, len(info))
+			lines := make([]string, 0, len(info))
+			for _, property := range info {
+				values[property.name] = property.value
+				lines = append(lines, property.name+": "+property.value)
+			}
+			return values, strings.Join(lines, "\n"), nil
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/spf13/cobra"
+)
+
+// inputCmd represents the input command
+var inputCmd = &cobra.Command{
+	Use:   "input",
+	Short: "Show, list and select inputs",
+}
+
+type inputOutput struct {
+	Input string `json:"input"`
+	Raw   string `json:"raw"`
+}
+
+var inputGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Display the selected input",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			in, err := proj.GetInputContext(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+			return inputOutput{Input: in.String(), Raw: in.Raw()}, in.String(), nil
+		})
+	},
+}
+
+var inputSetCmd = &cobra.Command{
+	Use:   "set <input>",
+	Short: "Select an input by name, e.g. digital1, or as raw parameter, e.g. 31",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, err := pjlink.ParseHumanInput(args[0])
+		if err != nil {
+			if in, err = pjlink.ParseInput(args[0]); err != nil {
+				return err
+			}
+		}
+
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			return nil, "", proj.SetInputContext(ctx, in)
+		})
+	},
+}
+
+var inputListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the inputs of the Projector",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			inputs, err := proj.InputsContext(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+			list := make([]inputOutput, 0, len(inputs))
+			names := make([]string, 0, len(inputs))
+			for _, in := range inputs {
+				list = append(list, inputOutput{Input: in.String(), Raw: in.Raw()})
+				names = append(names, in.String())
+			}
+			return list, strings.Join(names, "\n"), nil
+		})
+	},
+}
+
+func init() {
+	inputCmd.AddCommand(inputGetCmd, inputSetCmd, inputListCmd)
+	rootCmd.AddCommand(inputCmd)
+}
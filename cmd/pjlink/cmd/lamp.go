@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/spf13/cobra"
+)
+
+// lampCmd represents the lamp command
+var lampCmd = &cobra.Command{
+	Use:   "lamp",
+	Short: "Display lighting hours and state of the lamps",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			lamps, err := proj.LampsContext(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+			lines := make([]string, 0, len(lamps))
+			for i, lamp := range lamps {
+				lines = append(lines, fmt.Sprintf("lamp %d: %d hours, %s", i+1, lamp.Hours, onOff(lamp.On)))
+			}
+			return lamps, strings.Join(lines, "\n"), nil
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lampCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/spf13/cobra"
+)
+
+// muteCmd represents the mute command
+var muteCmd = &cobra.Command{
+	Use:   "mute [video|audio|av] [on|off]",
+	Short: "Display or change video and audio mute",
+	Long: `Display or change video and audio mute.
+
+Without arguments the current state is displayed, e.g.
+
+  pjlink mute
+  pjlink mute video on
+  pjlink mute av off`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return nil
+		}
+		if len(args) != 2 {
+			return fmt.Errorf("expected [video|audio|av] [on|off], got %d arguments", len(args))
+		}
+		if args[0] != "video" && args[0] != "audio" && args[0] != "av" {
+			return fmt.Errorf("invalid mute %q, expected video, audio or av", args[0])
+		}
+		if args[1] != "on" && args[1] != "off" {
+			return fmt.Errorf("invalid state %q, expected on or off", args[1])
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			if len(args) == 0 {
+				mute, err := proj.GetAVMuteContext(ctx)
+				if err != nil {
+					return nil, "", err
+				}
+				return mute, fmt.Sprintf("video: %s\naudio: %s", onOff(mute.Video), onOff(mute.Audio)), nil
+			}
+
+			on := args[1] == "on"
+			switch args[0] {
+			case "video":
+				return nil, "", proj.SetVideoMuteContext(ctx, on)
+			case "audio":
+				return nil, "", proj.SetAudioMuteContext(ctx, on)
+			}
+			return nil, "", proj.SetAVMuteContext(ctx, on)
+		})
+	},
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+func init() {
+	rootCmd.AddCommand(muteCmd)
+}
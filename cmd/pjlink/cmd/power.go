@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/spf13/cobra"
+)
+
+// powerCmd represents the power command
+var powerCmd = &cobra.Command{
+	Use:   "power",
+	Short: "Turn the Projector on or off and show its power state",
+}
+
+var powerOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Turn the Projector on",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			return nil, "", proj.TurnOnContext(ctx)
+		})
+	},
+}
+
+var powerOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Turn the Projector off",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			return nil, "", proj.TurnOffContext(ctx)
+		})
+	},
+}
+
+var powerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Display the power state: off, on, cooling or warm-up",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			state, err := proj.PowerContext(ctx)
+			if err != nil {
+				return nil, "", err
+			}
+			return map[string]string{"power": state.String()}, state.String(), nil
+		})
+	},
+}
+
+func init() {
+	powerCmd.AddCommand(powerOnCmd, powerOffCmd, powerStatusCmd)
+	rootCmd.AddCommand(powerCmd)
+}
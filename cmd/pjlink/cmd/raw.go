@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/spf13/cobra"
+)
+
+// rawCmd represents the raw command
+var rawCmd = &cobra.Command{
+	Use:   "raw <class> <command> <parameter>",
+	Short: "Send a PJLink command as is and display the answer",
+	Long: `Send a PJLink command as is and display the answer, e.g.
+
+  pjlink raw 1 POWR ?
+  pjlink raw 2 FREZ 1
+
+Error codes of the projector end the command with their exit code.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		class, err := strconv.Atoi(args[0])
+		if err != nil {
+			return &pjlink.RequestError{Command: args[1], Reason: "Invalid class: " + args[0]}
+		}
+
+		return run(cmd, func(ctx context.Context, proj *pjlink.PJProjector) (any, string, error) {
+			resp, err := proj.SendRequestContext(ctx, pjlink.PJRequest{Class: class, Command: strings.ToUpper(args[1]), Parameter: args[2]})
+			if err != nil {
+				return nil, "", err
+			}
+			return resp, strings.Join(resp.Response, " "), resp.Err()
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rawCmd)
+}
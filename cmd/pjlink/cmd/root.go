@@ -0,0 +1,163 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cfgFile string
+
+// Exit codes, errors the projector answered with get their own code so scripts can react to them
+const (
+	exitFailure        = 1
+	exitUndefined      = 11 // ERR1
+	exitOutOfParameter = 12 // ERR2
+	exitUnavailable    = 13 // ERR3
+	exitDeviceFailure  = 14 // ERR4
+	exitAuthentication = 15 // ERRA
+	exitNetwork        = 16
+)
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   "pjlink",
+	Short: "Control projectors with PJLink",
+	Long: `Control projectors with PJLink.
+
+The projector is given with --projectorIp and --password-env or --password-file,
+or the same keys in the config file or in PJLINK_ environment variables, e.g.
+PJLINK_PROJECTORIP and PJLINK_PASSWORD_FILE. Projectors named in an inventory file are given
+with --target and --group, e.g.
+
+  pjlink --inventory projectors.yaml --group lecture-halls power off
+
+Errors the projector answers with end the command with these exit codes:
+
+  11  ERR1 undefined command
+  12  ERR2 out of parameter
+  13  ERR3 unavailable time, e.g. while warming up
+  14  ERR4 projector failure
+  15  ERRA authentication failed
+  16  network error`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(exitCode(err))
+	}
+}
+
+func init() {
+	cobra.OnInitialize(initConfig)
+
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.pjlink.yaml)")
+	rootCmd.PersistentFlags().String("projectorIp", "", "Ip of the Projector")
+	rootCmd.PersistentFlags().String("port", "4352", "PJLink port of the Projector")
+	rootCmd.PersistentFlags().String("password", "", "Password of the Projector")
+	rootCmd.PersistentFlags().MarkDeprecated("password", "it is visible in process listings and shell history, use --password-env or --password-file")
+	rootCmd.PersistentFlags().String("password-env", "", "environment variable holding the password of the Projector")
+	rootCmd.PersistentFlags().String("password-file", "", "file holding the password of the Projector, only readable by its owner")
+	rootCmd.PersistentFlags().String("inventory", "", "inventory file of named projectors, YAML or TOML")
+	rootCmd.PersistentFlags().StringSlice("target", nil, "name of a projector in the inventory, may be repeated")
+	rootCmd.PersistentFlags().StringSlice("group", nil, "tag of projectors in the inventory, may be repeated")
+	rootCmd.PersistentFlags().Int("class", 1, "PJLink class of the Projector, 2 enables Class 2 commands")
+	rootCmd.PersistentFlags().Duration("timeout", 10*time.Second, "limit of a command")
+	rootCmd.PersistentFlags().Bool("json", false, "print results as JSON")
+	rootCmd.PersistentFlags().Bool("trace", false, "hex dump the traffic with the projectors to stderr, the password digest is redacted")
+
+	for _, name := range []string{"projectorIp", "port", "password", "password-env", "password-file", "inventory", "target", "group", "class", "timeout", "json", "trace"} {
+		viper.BindPFlag(name, rootCmd.PersistentFlags().Lookup(name))
+	}
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig() {
+	if cfgFile != "" {
+		// Use config file from the flag.
+		viper.SetConfigFile(cfgFile)
+	} else {
+		// Find home directory.
+		home, err := homedir.Dir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitFailure)
+		}
+
+		// Search config in home directory with name ".pjlink" (without extension).
+		viper.AddConfigPath(home)
+		viper.SetConfigName(".pjlink")
+	}
+
+	// read in environment variables that match with the PJLINK_ prefix, e.g. PJLINK_PORT or
+	// PJLINK_PASSWORD_FILE, so unrelated variables such as PORT don't change the defaults
+	viper.SetEnvPrefix("PJLINK")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv()
+
+	// If a config file is found, read it in.
+	if err := viper.ReadInConfig(); err == nil && !viper.GetBool("json") {
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	}
+}
+
+// prints v as JSON with --json and text otherwise
+func output(cmd *cobra.Command, v any, text string) error {
+	if viper.GetBool("json") {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	_, err := fmt.Fprintln(cmd.OutOrStdout(), text)
+	return err
+}
+
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, pjlink.ErrUndefinedCommand):
+		return exitUndefined
+	case errors.Is(err, pjlink.ErrOutOfParameter):
+		return exitOutOfParameter
+	case errors.Is(err, pjlink.ErrUnavailableTime):
+		return exitUnavailable
+	case errors.Is(err, pjlink.ErrDeviceFailure):
+		return exitDeviceFailure
+	case errors.Is(err, pjlink.ErrAuthentication):
+		return exitAuthentication
+	case errors.Is(err, pjlink.ErrNetwork), errors.Is(err, context.DeadlineExceeded):
+		return exitNetwork
+	}
+	return exitFailure
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestEnvironment(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	initConfig()
+
+	// unrelated variables don't override the defaults
+	t.Setenv("PORT", "9")
+	t.Setenv("CLASS", "2")
+	if proj := flagProjector("127.0.0.1"); proj.Port != "4352" || proj.Class != 1 {
+		t.Errorf("with PORT and CLASS set the projector is %v, want port 4352 and class 1", proj)
+	}
+
+	t.Setenv("PJLINK_PORT", "4353")
+	t.Setenv("PJLINK_PASSWORD_ENV", "ROOM_PASSWORD")
+	proj := flagProjector("127.0.0.1")
+	if proj.Port != "4353" {
+		t.Errorf("port = %s, want 4353 from PJLINK_PORT", proj.Port)
+	}
+	if proj.Credentials == nil {
+		t.Error("PJLINK_PASSWORD_ENV didn't set the credentials")
+	}
+}
@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/inventory"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/peterh/liner"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// human readable parameters of each command, e.g. "power on" sends POWR 1
+var requestMaps = map[string]map[string]string{
+	"POWR": pjlink.PowerRequests,
+	"INST": pjlink.InputListRequests,
+	"INPT": pjlink.InputRequests,
+	"AVMT": pjlink.AVMuteRequests,
+	"ERST": pjlink.ErrorStatusRequests,
+	"LAMP": pjlink.LampRequests,
+	"NAME": pjlink.NameRequests,
+	"INF1": pjlink.ManufacturerRequests,
+	"INF2": pjlink.ModelRequests,
+	"INFO": pjlink.VersionRequests,
+	"SNUM": pjlink.SerialNumberRequests,
+	"SVER": pjlink.SoftwareVersionRequests,
+	"INNM": pjlink.InputNameRequests,
+	"IRES": pjlink.ResolutionRequests,
+	"RRES": pjlink.ResolutionRequests,
+	"FILT": pjlink.FilterUsageRequests,
+	"RLMP": pjlink.ReplacementModelRequests,
+	"RFIL": pjlink.ReplacementModelRequests,
+	"SVOL": pjlink.VolumeRequests,
+	"MVOL": pjlink.VolumeRequests,
+	"FREZ": pjlink.FreezeRequests,
+}
+
+// meaning of the answers to queries, the other commands are decoded in decodeResponse
+var queryResponseMaps = map[string]map[string]string{
+	"POWR": pjlink.PowerQueryResponses,
+	"INPT": pjlink.InputQueryResponses,
+	"AVMT": pjlink.AVMuteQueryResponses,
+	"FREZ": pjlink.FreezeQueryResponses,
+}
+
+// meaning of the answers to commands that change something
+var setResponseMaps = map[string]map[string]string{
+	"POWR": pjlink.PowerResponses,
+	"INPT": pjlink.InputResponses,
+	"AVMT": pjlink.AVMuteResponses,
+	"SVOL": pjlink.VolumeResponses,
+	"MVOL": pjlink.VolumeResponses,
+	"FREZ": pjlink.FreezeResponses,
+}
+
+const shellHelp = `Commands are sent over a single session and answered with the decoded response:
+
+  POWR ?          raw command, the parameter defaults to ?
+  INPT 31
+  %2INPT 3A       raw command with explicit class
+  power on        human readable names, tab completes commands and parameters
+  input digital1
+  help            this text
+  exit            end the session, as does Ctrl-D`
+
+// shellCmd represents the shell command
+var shellCmd = &cobra.Command{
+	Use:   "shell [address]",
+	Short: "Send commands interactively over a persistent session",
+	Long: `Send commands interactively over a persistent session.
+
+The address is a name in the inventory, an IP or IP:port, projectorIp is used
+if it is left out.
+
+` + shellHelp,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		proj, err := shellProjector(args)
+		if err != nil {
+			return err
+		}
+		proj.Session = true
+		setTracer(proj)
+		defer proj.Close()
+
+		line := liner.NewLiner()
+		defer line.Close()
+		line.SetCtrlCAborts(true)
+		line.SetCompleter(completeShell)
+
+		historyPath := shellHistoryPath()
+		if file, err := os.Open(historyPath); err == nil {
+			line.ReadHistory(file)
+			file.Close()
+		}
+		defer func() {
+			if file, err := os.OpenFile(historyPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600); err == nil {
+				line.WriteHistory(file)
+				file.Close()
+			}
+		}()
+
+		out := cmd.OutOrStdout()
+		prompt := proj.Address + "> "
+		for {
+			input, err := line.Prompt(prompt)
+			if errors.Is(err, io.EOF) || errors.Is(err, liner.ErrPromptAborted) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			input = strings.TrimSpace(input)
+			if input == "" {
+				continue
+			}
+			line.AppendHistory(input)
+
+			switch strings.ToLower(input) {
+			case "exit", "quit":
+				return nil
+			case "help", "?":
+				fmt.Fprintln(out, shellHelp)
+				continue
+			}
+
+			request, err := parseShellLine(input, proj.Class)
+			if err != nil {
+				fmt.Fprintln(out, "error:", err)
+				continue
+			}
+			resp, err := sendShellRequest(cmd.Context(), proj, request)
+			if err != nil {
+				fmt.Fprintln(out, "error:", err)
+				continue
+			}
+			fmt.Fprintf(out, "%%%s%s=%s\n", resp.Class, resp.Command, strings.Join(resp.Response, " "))
+			if decoded := decodeResponse(request, resp); decoded != "" {
+				fmt.Fprintln(out, "  "+strings.ReplaceAll(decoded, "\n", "\n  "))
+			}
+		}
+	},
+}
+
+// sends request within the limit of --timeout
+func sendShellRequest(ctx context.Context, proj *pjlink.PJProjector, request pjlink.PJRequest) (*pjlink.PJResponse, error) {
+	if timeout := viper.GetDuration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return proj.SendRequestContext(ctx, request)
+}
+
+// the projector named by the argument, looked up in the inventory first
+func shellProjector(args []string) (*pjlink.PJProjector, error) {
+	if len(args) == 0 {
+		projectorIp := viper.GetString("projectorIp")
+		if projectorIp == "" {
+			return nil, errors.New("an address or projectorIp has to be specified")
+		}
+		return flagProjector(projectorIp), nil
+	}
+
+	if path := viper.GetString("inventory"); path != "" {
+		inv, err := inventory.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := inv.Projectors[args[0]]; ok {
+			return inv.Projector(args[0])
+		}
+	}
+
+	host, port, err := net.SplitHostPort(args[0])
+	if err != nil {
+		return flagProjector(args[0]), nil
+	}
+	proj := flagProjector(host)
+	proj.Port = port
+	return proj, nil
+}
+
+// turns "POWR ?", "%2INPT 3A" or "power on" into a request
+func parseShellLine(input string, class int) (pjlink.PJRequest, error) {
+	fields := strings.Fields(input)
+	word, parameter := fields[0], strings.Join(fields[1:], " ")
+
+	request := pjlink.PJRequest{}
+	if strings.HasPrefix(word, "%") && len(word) == 6 {
+		requestClass, err := strconv.Atoi(word[1:2])
+		if err != nil {
+			return request, &pjlink.RequestError{Command: word, Reason: "Invalid class: " + word[1:2]}
+		}
+		request.Class, request.Command = requestClass, strings.ToUpper(word[2:])
+	} else if command, ok := pjlink.HumanToRawCommands[strings.ToLower(word)]; ok {
+		request.Command = command
+		parameter = humanParameter(strings.ToLower(word), command, parameter)
+	} else {
+		request.Command = strings.ToUpper(word)
+	}
+
+	if request.Class == 0 {
+		request.Class = commandClass(request.Command, class)
+	}
+	request.Parameter = parameter
+	if request.Parameter == "" {
+		request.Parameter = "?"
+	}
+	return request, nil
+}
+
+// Class 1 unless the command only exists in Class 2, or the projector supports the Class 2
+// forms of INPT, INST and AVMT
+func commandClass(command string, class int) int {
+	if !pjlink.CommandMapClass1[command] || (class >= 2 && pjlink.CommandMapClass2[command]) {
+		return 2
+	}
+	return 1
+}
+
+// translates e.g. "on" of "power on" to "1", parameters that aren't known are sent as they are
+func humanParameter(human string, command string, parameter string) string {
+	requests := requestMaps[command]
+	for _, key := range []string{parameter, human + "-" + parameter, "volume-" + parameter} {
+		if raw, ok := requests[key]; ok {
+			return raw
+		}
+	}
+	return parameter
+}
+
+// the meaning of a response according to the maps of the pjlink package, empty if there is nothing to add
+func decodeResponse(request pjlink.PJRequest, resp *pjlink.PJResponse) string {
+	if len(resp.Response) == 0 {
+		return ""
+	}
+	first := resp.Response[0]
+	if meaning, ok := pjlink.ErrorResponses[first]; ok {
+		return meaning
+	}
+	if request.Parameter != "?" {
+		return setResponseMaps[request.Command][first]
+	}
+
+	switch request.Command {
+	case "INPT":
+		if in, err := pjlink.ParseInput(first); err == nil {
+			return in.String()
+		}
+	case "INST":
+		names := make([]string, 0, len(resp.Response))
+		for _, raw := range resp.Response {
+			if in, err := pjlink.ParseInput(raw); err == nil {
+				names = append(names, in.String())
+			}
+		}
+		return strings.Join(names, " ")
+	case "ERST":
+		status, err := pjlink.ParseErrorStatus(first)
+		if err != nil {
+			return err.Error()
+		}
+		lines := make([]string, 0, len(pjlink.ErrorStatusComponents))
+		for _, component := range pjlink.ErrorStatusComponents {
+			level, _ := status.Level(component)
+			lines = append(lines, component+": "+level.String())
+		}
+		return strings.Join(lines, "\n")
+	case "LAMP":
+		lamps, err := pjlink.ParseLamps(resp.Response)
+		if err != nil {
+			return err.Error()
+		}
+		lines := make([]string, 0, len(lamps))
+		for i, lamp := range lamps {
+			state := "off"
+			if lamp.On {
+				state = "on"
+			}
+			lines = append(lines, fmt.Sprintf("lamp %d: %d hours, %s", i+1, lamp.Hours, state))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return queryResponseMaps[request.Command][first]
+}
+
+// completes commands in the first word and their human readable parameters in the second
+func completeShell(line string) []string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || (len(fields) == 1 && !strings.HasSuffix(line, " ")) {
+		prefix := ""
+		if len(fields) == 1 {
+			prefix = fields[0]
+		}
+		return withPrefix(shellWords(), prefix, "")
+	}
+	if len(fields) > 2 || (len(fields) == 2 && strings.HasSuffix(line, " ")) {
+		return nil
+	}
+
+	word := fields[0]
+	command, ok := pjlink.HumanToRawCommands[strings.ToLower(word)]
+	if !ok {
+		command = strings.ToUpper(word)
+	}
+	parameters := make([]string, 0)
+	for parameter := range requestMaps[command] {
+		if parameter != "query" {
+			parameters = append(parameters, parameter)
+		}
+	}
+	sort.Strings(parameters)
+
+	prefix := ""
+	if len(fields) == 2 {
+		prefix = fields[1]
+	}
+	return withPrefix(parameters, prefix, word+" ")
+}
+
+// raw and human readable commands and the commands of the shell, sorted
+func shellWords() []string {
+	words := []string{"exit", "help"}
+	for command := range pjlink.CommandMapClass1 {
+		words = append(words, command)
+	}
+	for command := range pjlink.CommandMapClass2 {
+		if !pjlink.CommandMapClass1[command] {
+			words = append(words, command)
+		}
+	}
+	for human := range pjlink.HumanToRawCommands {
+		words = append(words, human)
+	}
+	sort.Strings(words)
+	return words
+}
+
+func withPrefix(candidates []string, prefix string, lead string) []string {
+	matches := make([]string, 0)
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(prefix)) {
+			matches = append(matches, lead+candidate)
+		}
+	}
+	return matches
+}
+
+func shellHistoryPath() string {
+	home, err := homedir.Dir()
+	if err != nil {
+		return ".pjlink_history"
+	}
+	return filepath.Join(home, ".pjlink_history")
+}
+
+func init() {
+	rootCmd.AddCommand(shellCmd)
+}
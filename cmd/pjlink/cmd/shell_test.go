@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+func TestParseShellLine(t *testing.T) {
+	tests := []struct {
+		input string
+		class int
+		want  pjlink.PJRequest
+	}{
+		{"POWR ?", 1, pjlink.PJRequest{Class: 1, Command: "POWR", Parameter: "?"}},
+		{"powr", 1, pjlink.PJRequest{Class: 1, Command: "POWR", Parameter: "?"}},
+		{"INPT 31", 1, pjlink.PJRequest{Class: 1, Command: "INPT", Parameter: "31"}},
+		{"%2INPT 3A", 1, pjlink.PJRequest{Class: 2, Command: "INPT", Parameter: "3A"}},
+		{"%1inpt 31", 2, pjlink.PJRequest{Class: 1, Command: "INPT", Parameter: "31"}},
+		// INPT, INST and AVMT go out as Class 2 to a Class 2 projector, Class 2 only commands always do
+		{"INPT 31", 2, pjlink.PJRequest{Class: 2, Command: "INPT", Parameter: "31"}},
+		{"AVMT 31", 2, pjlink.PJRequest{Class: 2, Command: "AVMT", Parameter: "31"}},
+		{"AVMT 31", 1, pjlink.PJRequest{Class: 1, Command: "AVMT", Parameter: "31"}},
+		{"POWR 1", 2, pjlink.PJRequest{Class: 1, Command: "POWR", Parameter: "1"}},
+		{"SNUM", 1, pjlink.PJRequest{Class: 2, Command: "SNUM", Parameter: "?"}},
+		// human readable commands and parameters
+		{"power on", 1, pjlink.PJRequest{Class: 1, Command: "POWR", Parameter: "1"}},
+		{"Power power-off", 1, pjlink.PJRequest{Class: 1, Command: "POWR", Parameter: "0"}},
+		{"input digital1", 1, pjlink.PJRequest{Class: 1, Command: "INPT", Parameter: "31"}},
+		{"av-mute video-mute-on", 1, pjlink.PJRequest{Class: 1, Command: "AVMT", Parameter: "11"}},
+		{"speaker-volume up", 2, pjlink.PJRequest{Class: 2, Command: "SVOL", Parameter: "1"}},
+		{"name", 1, pjlink.PJRequest{Class: 1, Command: "NAME", Parameter: "?"}},
+		// unknown parameters are sent as they are
+		{"input 9Z", 1, pjlink.PJRequest{Class: 1, Command: "INPT", Parameter: "9Z"}},
+		{"input-name digital 1", 2, pjlink.PJRequest{Class: 2, Command: "INNM", Parameter: "digital 1"}},
+	}
+	for _, test := range tests {
+		got, err := parseShellLine(test.input, test.class)
+		if err != nil || got != test.want {
+			t.Errorf("parseShellLine(%q, %d) = %+v, %v, want %+v", test.input, test.class, got, err, test.want)
+		}
+	}
+
+	var reqErr *pjlink.RequestError
+	if _, err := parseShellLine("%xINPT 31", 1); !errors.As(err, &reqErr) {
+		t.Errorf("parseShellLine(%%xINPT 31) = %v, want a RequestError", err)
+	}
+}
+
+func TestDecodeResponse(t *testing.T) {
+	tests := []struct {
+		request  string
+		response []string
+		want     string
+	}{
+		{"POWR ?", []string{"1"}, "power-on (lamp on)"},
+		{"POWR 1", []string{"OK"}, "success, or already current state"},
+		{"POWR ?", []string{"ERR3"}, "unavailable time"},
+		{"INPT ?", []string{"3A"}, "digitalA"},
+		{"INST ?", []string{"11", "31", "bad"}, "rgb1 digital1"},
+		{"AVMT ?", []string{"31"}, pjlink.AVMuteQueryResponses["31"]},
+		{"ERST ?", []string{"020001"}, "fan: ok\nlamp: error\ntemperature: ok\ncover-open: ok\nfilter: ok\nother: warning"},
+		{"LAMP ?", []string{"1200", "1", "30", "0"}, "lamp 1: 1200 hours, on\nlamp 2: 30 hours, off"},
+		{"NAME ?", []string{"Hall", "A"}, ""},
+		{"POWR ?", nil, ""},
+	}
+	for _, test := range tests {
+		request, err := parseShellLine(test.request, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp := &pjlink.PJResponse{Command: request.Command, Response: test.response}
+		if got := decodeResponse(request, resp); got != test.want {
+			t.Errorf("decodeResponse(%s, %q) = %q, want %q", test.request, test.response, got, test.want)
+		}
+	}
+
+	// malformed answers say what is wrong with them
+	for request, response := range map[string][]string{"ERST ?": {"0200"}, "LAMP ?": {"1200"}} {
+		req, _ := parseShellLine(request, 1)
+		if got := decodeResponse(req, &pjlink.PJResponse{Response: response}); got == "" {
+			t.Errorf("decodeResponse(%s, %q) is empty, want the decoding error", request, response)
+		}
+	}
+}
+
+func TestCompleteShell(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"pow", []string{"POWR", "power"}},
+		{"ex", []string{"exit"}},
+		{"power ", []string{"power power-off", "power power-on"}},
+		{"power power-of", []string{"power power-off"}},
+		{"AVMT audio", []string{"AVMT audio-mute-off", "AVMT audio-mute-on"}},
+		{"speaker-volume volume-u", []string{"speaker-volume volume-up"}},
+		{"power on ", nil},
+		{"NAME ", []string{}},
+	}
+	for _, test := range tests {
+		if got := completeShell(test.line); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("completeShell(%q) = %q, want %q", test.line, got, test.want)
+		}
+	}
+
+	all := completeShell("")
+	if len(all) == 0 || all[0] > all[len(all)-1] {
+		t.Errorf("completeShell(\"\") = %q, want all words sorted", all)
+	}
+}
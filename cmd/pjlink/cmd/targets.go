@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/inventory"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// operation of a command on a single projector, value is printed unless it is nil
+type operation func(ctx context.Context, proj *pjlink.PJProjector) (value any, text string, err error)
+
+type outcome struct {
+	value any
+	text  string
+}
+
+// runs op against the targeted projectors and prints the results, prefixed with the name of
+// the projector if there is more than one
+func run(cmd *cobra.Command, op operation) error {
+	fleet, err := targets()
+	if err != nil {
+		return err
+	}
+	fleet.Timeout = viper.GetDuration("timeout")
+	for _, name := range fleet.Names() {
+		proj, _ := fleet.Projector(name)
+		setTracer(proj)
+	}
+
+	var mu sync.Mutex
+	outcomes := make(map[*pjlink.PJProjector]outcome)
+	report := fleet.Run(cmd.Context(), func(ctx context.Context, proj *pjlink.PJProjector) error {
+		value, text, err := op(ctx, proj)
+		mu.Lock()
+		outcomes[proj] = outcome{value: value, text: text}
+		mu.Unlock()
+		return err
+	})
+
+	if len(report) == 1 {
+		proj, _ := fleet.Projector(report[0].Name)
+		if result := outcomes[proj]; result.value != nil {
+			if err := output(cmd, result.value, result.text); err != nil {
+				return err
+			}
+		}
+		return report[0].Err
+	}
+
+	values := make(map[string]any)
+	lines := make([]string, 0, len(report))
+	errs := make([]error, 0)
+	for _, result := range report {
+		proj, _ := fleet.Projector(result.Name)
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Name, result.Err))
+			values[result.Name] = map[string]string{"error": result.Err.Error()}
+		}
+		if outcome := outcomes[proj]; outcome.value != nil {
+			values[result.Name] = outcome.value
+			lines = append(lines, prefixLines(result.Name, outcome.text))
+		}
+	}
+	if len(lines) > 0 || viper.GetBool("json") {
+		if err := output(cmd, values, strings.Join(lines, "\n")); err != nil {
+			return err
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// the projectors selected with --target and --group, or the one given with --projectorIp
+func targets() (*pjlink.Fleet, error) {
+	names, groups := viper.GetStringSlice("target"), viper.GetStringSlice("group")
+	if len(names) > 0 || len(groups) > 0 {
+		path := viper.GetString("inventory")
+		if path == "" {
+			return nil, errors.New("--target and --group need an --inventory")
+		}
+		inv, err := inventory.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if names, err = inv.Select(names, groups); err != nil {
+			return nil, err
+		}
+		return inv.Fleet(names...)
+	}
+
+	projectorIp := viper.GetString("projectorIp")
+	if projectorIp == "" {
+		return nil, errors.New("projectorIp, --target or --group has to be specified")
+	}
+	fleet := pjlink.NewFleet()
+	fleet.Add(projectorIp, flagProjector(projectorIp))
+	return fleet, nil
+}
+
+// the projector at address configured by the flags
+func flagProjector(address string) *pjlink.PJProjector {
+	proj := pjlink.NewProjector(address, viper.GetString("password"))
+	switch {
+	case viper.GetString("password-file") != "":
+		proj.Credentials = pjlink.FileCredentials(viper.GetString("password-file"))
+	case viper.GetString("password-env") != "":
+		proj.Credentials = pjlink.EnvCredentials(viper.GetString("password-env"))
+	}
+	proj.Port = viper.GetString("port")
+	proj.Class = viper.GetInt("class")
+	return proj
+}
+
+// traces the traffic of proj to stderr with --trace
+func setTracer(proj *pjlink.PJProjector) {
+	if viper.GetBool("trace") {
+		proj.Tracer = tracer
+	}
+}
+
+// shared by all projectors, so the dumps of concurrent requests don't interleave
+var tracer = pjlink.HexDumpTracer(os.Stderr)
+
+// "name: text" for a single line, otherwise the name followed by the indented lines
+func prefixLines(name string, text string) string {
+	if !strings.Contains(text, "\n") {
+		return name + ": " + text
+	}
+	return name + ":\n  " + strings.ReplaceAll(text, "\n", "\n  ")
+}
@@ -14,7 +14,7 @@
 
 package main
 
-import "github.com/LightInstruments/pjlink/test-cli/cmd"
+import "github.com/LightInstruments/pjlink/cmd/pjlink/cmd"
 
 func main() {
 	cmd.Execute()
@@ -0,0 +1,89 @@
+package pjlink_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+// a device that accepts connections, optionally greets and then never answers
+func hungDevice(t *testing.T, greeting string) *pjlink.PJProjector {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				conn.Write([]byte(greeting))
+				io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	pr := pjlink.NewProjector(host, "")
+	pr.Port = port
+	return pr
+}
+
+func TestContextCancelsHungDevice(t *testing.T) {
+	for name, greeting := range map[string]string{"greeting": "", "response": "PJLINK 0\r"} {
+		for _, session := range []bool{false, true} {
+			pr := hungDevice(t, greeting)
+			pr.Session = session
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+			start := time.Now()
+			_, err := pr.PowerContext(ctx)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("hung %s, session %t: PowerContext() = %v, want context.Canceled", name, session, err)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("hung %s, session %t: cancel took %v", name, session, elapsed)
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+			_, err = pr.PowerContext(ctx)
+			cancel()
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("hung %s, session %t: PowerContext() past the deadline = %v, want context.DeadlineExceeded", name, session, err)
+			}
+			pr.Close()
+		}
+	}
+}
+
+func TestContextDoneBeforeRequest(t *testing.T) {
+	pr := hungDevice(t, "PJLINK 0\r")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := pr.TurnOnContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("TurnOnContext() with a cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestReadTimeoutWithoutContext(t *testing.T) {
+	pr := hungDevice(t, "")
+	pr.ReadTimeout = 50 * time.Millisecond
+	start := time.Now()
+	if _, err := pr.Power(); !errors.Is(err, pjlink.ErrNetwork) {
+		t.Errorf("Power() of a device that doesn't greet = %v, want a network error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Power() took %v with a 50ms read timeout", elapsed)
+	}
+}
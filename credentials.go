@@ -0,0 +1,125 @@
+package pjlink
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// CredentialProvider supplies the password of a Projector. It is consulted on every
+// authentication challenge, so rotated passwords are picked up without a restart.
+type CredentialProvider interface {
+	// Password returns the password of the Projector at address, the host without the port
+	Password(ctx context.Context, address string) (string, error)
+}
+
+// CredentialFunc adapts a function to a CredentialProvider
+type CredentialFunc func(ctx context.Context, address string) (string, error)
+
+func (f CredentialFunc) Password(ctx context.Context, address string) (string, error) {
+	return f(ctx, address)
+}
+
+// EnvCredentials reads the password from the environment variable name
+func EnvCredentials(name string) CredentialProvider {
+	return CredentialFunc(func(ctx context.Context, address string) (string, error) {
+		password, ok := os.LookupEnv(name)
+		if !ok {
+			return "", errors.New("environment variable " + name + " is not set")
+		}
+		return password, nil
+	})
+}
+
+// FileCredentials reads the password from the first line of the file at path, spaces are kept.
+// The file must not be accessible by group or others, like an ssh key.
+func FileCredentials(path string) CredentialProvider {
+	return CredentialFunc(func(ctx context.Context, address string) (string, error) {
+		lines, err := readSecretFile(path)
+		if err != nil {
+			return "", err
+		}
+		if len(lines) == 0 {
+			return "", errors.New(path + " is empty")
+		}
+		return lines[0], nil
+	})
+}
+
+// HostFileCredentials reads the password of each Projector from a file with one
+// "<host> <password>" pair per line, separated by spaces or tabs. "*" as host matches
+// Projectors without their own line. Empty lines and lines starting with # are skipped.
+// The file must not be accessible by group or others, like an ssh key.
+func HostFileCredentials(path string) CredentialProvider {
+	return CredentialFunc(func(ctx context.Context, address string) (string, error) {
+		lines, err := readSecretFile(path)
+		if err != nil {
+			return "", err
+		}
+
+		fallback, found := "", false
+		for _, line := range lines {
+			line = strings.TrimLeft(line, " \t")
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			// the password starts after the whitespace following the host and may contain spaces itself
+			host, password := line, ""
+			if i := strings.IndexAny(line, " \t"); i >= 0 {
+				host, password = line[:i], strings.TrimLeft(line[i:], " \t")
+			}
+			switch host {
+			case address:
+				return password, nil
+			case "*":
+				fallback, found = password, true
+			}
+		}
+		if !found {
+			return "", errors.New("no password for " + address + " in " + path)
+		}
+		return fallback, nil
+	})
+}
+
+// lines of a file holding secrets without their line endings, after checking that only its
+// owner can read it. Other whitespace belongs to the secrets.
+func readSecretFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	// Windows has no permission bits to check
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("permissions %04o of %s are too open, it must only be accessible by its owner", info.Mode().Perm(), path)
+	}
+
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	return lines, scanner.Err()
+}
+
+// the password for an authentication challenge, from Credentials if set
+func (pr *PJProjector) password(ctx context.Context) (string, error) {
+	if pr.Credentials == nil {
+		return pr.Password, nil
+	}
+	password, err := pr.Credentials.Password(ctx, pr.Address)
+	if err != nil {
+		return "", &CredentialError{Err: err}
+	}
+	return password, nil
+}
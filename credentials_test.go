@@ -0,0 +1,109 @@
+package pjlink_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+// writes a secret file only its owner can read
+func writeSecret(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("PJLINK_TEST_PASSWORD", " with spaces ")
+	if password, err := pjlink.EnvCredentials("PJLINK_TEST_PASSWORD").Password(context.Background(), "10.0.0.5"); err != nil || password != " with spaces " {
+		t.Errorf("Password() = %q, %v, want the variable as it is", password, err)
+	}
+	if _, err := pjlink.EnvCredentials("PJLINK_TEST_UNSET").Password(context.Background(), "10.0.0.5"); err == nil {
+		t.Error("no error for an unset variable")
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	tests := map[string]string{ // content and the password read from it
+		"secret\n":           "secret",
+		"secret":             "secret",
+		"secret\r\n":         "secret",
+		" with spaces \nx\n": " with spaces ",
+	}
+	for content, want := range tests {
+		password, err := pjlink.FileCredentials(writeSecret(t, content)).Password(context.Background(), "10.0.0.5")
+		if err != nil || password != want {
+			t.Errorf("Password() of %q = %q, %v, want %q", content, password, err, want)
+		}
+	}
+
+	if _, err := pjlink.FileCredentials(writeSecret(t, "")).Password(context.Background(), "10.0.0.5"); err == nil {
+		t.Error("no error for an empty file")
+	}
+}
+
+func TestHostFileCredentials(t *testing.T) {
+	path := writeSecret(t, strings.Join([]string{
+		"# hall passwords",
+		"",
+		"10.0.0.5 secret",
+		"10.0.0.6\tprojector",
+		"10.0.0.7 \t two words ",
+		"*  fallback",
+	}, "\n"))
+	tests := map[string]string{
+		"10.0.0.5": "secret",
+		"10.0.0.6": "projector",
+		"10.0.0.7": "two words ",
+		"10.0.0.9": "fallback",
+	}
+	credentials := pjlink.HostFileCredentials(path)
+	for address, want := range tests {
+		if password, err := credentials.Password(context.Background(), address); err != nil || password != want {
+			t.Errorf("Password(%s) = %q, %v, want %q", address, password, err, want)
+		}
+	}
+
+	if _, err := pjlink.HostFileCredentials(writeSecret(t, "10.0.0.5 secret\n")).Password(context.Background(), "10.0.0.6"); err == nil {
+		t.Error("no error for a host without a line and no *")
+	}
+}
+
+func TestSecretFilePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows has no permission bits")
+	}
+	path := writeSecret(t, "10.0.0.5 secret\n")
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for name, credentials := range map[string]pjlink.CredentialProvider{
+		"FileCredentials":     pjlink.FileCredentials(path),
+		"HostFileCredentials": pjlink.HostFileCredentials(path),
+	} {
+		if _, err := credentials.Password(context.Background(), "10.0.0.5"); err == nil || !strings.Contains(err.Error(), "too open") {
+			t.Errorf("%s of a file readable by others = %v, want an error", name, err)
+		}
+	}
+}
+
+func TestCredentialsAuthenticate(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Password: " spaced "})
+	defer srv.Close()
+
+	pr := srv.Projector()
+	pr.Password = ""
+	pr.Credentials = pjlink.FileCredentials(writeSecret(t, " spaced \n"))
+	if _, err := pr.Power(); err != nil {
+		t.Errorf("Power() with the password from a file = %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package pjlink
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DiscoveredProjector answered a Class 2 search (SRCH) with its MAC address (ACKN)
+type DiscoveredProjector struct {
+	Address string `json:"address"`
+	MAC     string `json:"mac"`
+
+	// filled by Identify
+	Name         string `json:"name,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Model        string `json:"model,omitempty"`
+	Class        int    `json:"class,omitempty"`
+}
+
+// Discover broadcasts a search on the local network and collects the answers until timeout or ctx is done
+func Discover(ctx context.Context, timeout time.Duration) ([]DiscoveredProjector, error) {
+	return DiscoverBroadcast(ctx, net.JoinHostPort("255.255.255.255", pjLinkPort), timeout)
+}
+
+// DiscoverBroadcast is Discover for a specific broadcast address, e.g. "10.1.2.255:4352" for another subnet
+func DiscoverBroadcast(ctx context.Context, broadcast string, timeout time.Duration) ([]DiscoveredProjector, error) {
+	target, err := net.ResolveUDPAddr("udp4", broadcast)
+	if err != nil {
+		return nil, &NetworkError{Address: broadcast, Command: "SRCH", Op: "resolve", Err: err}
+	}
+
+	// projectors may answer to the PJLink port instead of the port the search came from, so try to bind it
+	conn, err := net.ListenPacket("udp4", ":"+pjLinkPort)
+	if err != nil {
+		conn, err = net.ListenPacket("udp4", ":0")
+	}
+	if err != nil {
+		return nil, &NetworkError{Address: broadcast, Command: "SRCH", Op: "listen", Err: err}
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	if _, err := conn.WriteTo([]byte("%2SRCH\r"), target); err != nil {
+		return nil, &NetworkError{Address: broadcast, Command: "SRCH", Op: "write", Err: err}
+	}
+
+	found := make([]DiscoveredProjector, 0)
+	seen := make(map[string]bool)
+	buf := make([]byte, 1024)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return found, ctx.Err()
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return found, nil // collected everything that answered in time
+			}
+			return found, &NetworkError{Address: broadcast, Command: "SRCH", Op: "read", Err: err}
+		}
+
+		mac, ok := parseSearchAck(string(buf[:n]))
+		address := hostOf(addr)
+		if !ok || seen[address] {
+			continue
+		}
+		seen[address] = true
+		found = append(found, DiscoveredProjector{Address: address, MAC: mac})
+	}
+}
+
+// decodes "%2ACKN=<MAC>", our own broadcast coming back is ignored
+func parseSearchAck(raw string) (mac string, ok bool) {
+	resp := NewPJResponse()
+	if err := resp.Parse(strings.TrimRight(raw, "\r\n")); err != nil || resp.Command != "ACKN" {
+		return "", false
+	}
+	if _, err := net.ParseMAC(resp.Response[0]); err != nil {
+		return "", false
+	}
+	return strings.ToLower(resp.Response[0]), true
+}
+
+// Projector returns a client for the discovered projector
+func (d *DiscoveredProjector) Projector(password string) *PJProjector {
+	pr := NewProjector(d.Address, password)
+	pr.Class = d.Class
+	return pr
+}
+
+// Identity is what a projector reports about itself
+type Identity struct {
+	Name         string `json:"name"`         // NAME
+	Manufacturer string `json:"manufacturer"` // INF1
+	Model        string `json:"model"`        // INF2
+	Info         string `json:"info"`         // INFO
+	Class        int    `json:"class"`        // CLSS
+}
+
+func (pr *PJProjector) Identify() (Identity, error) {
+	return pr.IdentifyContext(context.Background())
+}
+
+// IdentifyContext asks the projector for NAME, INF1, INF2, INFO and CLSS
+func (pr *PJProjector) IdentifyContext(ctx context.Context) (Identity, error) {
+	var id Identity
+	var err error
+	for _, field := range []struct {
+		command string
+		value   *string
+	}{
+		{"NAME", &id.Name},
+		{"INF1", &id.Manufacturer},
+		{"INF2", &id.Model},
+		{"INFO", &id.Info},
+	} {
+		if *field.value, err = pr.queryText(ctx, 1, field.command); err != nil {
+			return id, err
+		}
+	}
+	class, err := pr.queryText(ctx, 1, "CLSS")
+	if err != nil {
+		return id, err
+	}
+	if id.Class, err = strconv.Atoi(class); err != nil {
+		return id, pr.annotate(&ResponseError{Response: class, Reason: "Invalid class: " + class}, "CLSS")
+	}
+	return id, nil
+}
+
+// Identify asks the projector for NAME, INF1, INF2 and CLSS
+func (d *DiscoveredProjector) Identify(ctx context.Context, password string) error {
+	pr := d.Projector(password)
+	pr.Session = true
+	defer pr.Close()
+
+	id, err := pr.IdentifyContext(ctx)
+	if err != nil {
+		return err
+	}
+	d.Name, d.Manufacturer, d.Model, d.Class = id.Name, id.Manufacturer, id.Model, id.Class
+	return nil
+}
+
+// DiscoverInventory discovers the projectors and identifies each of them, 8 at a time like a Fleet.
+// Projectors that could not be identified are still returned, their errors are joined.
+func DiscoverInventory(ctx context.Context, timeout time.Duration, password string) ([]DiscoveredProjector, error) {
+	found, err := Discover(ctx, timeout)
+	if err != nil {
+		return found, err
+	}
+	return found, identifyAll(ctx, found, func(ctx context.Context, d *DiscoveredProjector) error {
+		return d.Identify(ctx, password)
+	})
+}
+
+// runs identify for each projector with at most defaultParallelism of them at once
+func identifyAll(ctx context.Context, found []DiscoveredProjector, identify func(context.Context, *DiscoveredProjector) error) error {
+	slots := make(chan struct{}, defaultParallelism)
+	var wg sync.WaitGroup
+	errs := make([]error, len(found))
+	for i := range found {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			errs[i] = identify(ctx, &found[i])
+		}(i)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
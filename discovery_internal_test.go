@@ -0,0 +1,66 @@
+package pjlink
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseSearchAck(t *testing.T) {
+	tests := []struct {
+		raw string
+		mac string
+		ok  bool
+	}{
+		{"%2ACKN=00:1A:2B:3C:4D:5E\r", "00:1a:2b:3c:4d:5e", true},
+		{"%2ACKN=00:1a:2b:3c:4d:5e", "00:1a:2b:3c:4d:5e", true},
+		{"%2SRCH\r", "", false}, // our own broadcast
+		{"%2ACKN=not-a-mac\r", "", false},
+		{"%2LKUP=00:1A:2B:3C:4D:5E\r", "", false},
+		{"garbage", "", false},
+	}
+	for _, test := range tests {
+		if mac, ok := parseSearchAck(test.raw); mac != test.mac || ok != test.ok {
+			t.Errorf("parseSearchAck(%q) = %q, %t, want %q, %t", test.raw, mac, ok, test.mac, test.ok)
+		}
+	}
+}
+
+func TestIdentifyAllIsBounded(t *testing.T) {
+	found := make([]DiscoveredProjector, 50)
+	var mu sync.Mutex
+	running, peak := 0, 0
+	failure := errors.New("no answer")
+
+	err := identifyAll(context.Background(), found, func(ctx context.Context, d *DiscoveredProjector) error {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+		d.Name = "identified"
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		if d == &found[3] {
+			return failure
+		}
+		return nil
+	})
+
+	if peak > defaultParallelism {
+		t.Errorf("%d projectors identified at once, want at most %d", peak, defaultParallelism)
+	}
+	if !errors.Is(err, failure) {
+		t.Errorf("identifyAll() = %v, want the failure of one projector", err)
+	}
+	for i, d := range found {
+		if d.Name != "identified" {
+			t.Errorf("projector %d wasn't identified", i)
+		}
+	}
+}
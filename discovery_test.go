@@ -0,0 +1,68 @@
+package pjlink_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+// a UDP address on the loopback interface nothing listens on
+func freeUDPAddr(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().String()
+}
+
+func TestDiscoverBroadcast(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Class: 2, MAC: "00:1A:2B:3C:4D:5E"})
+	defer srv.Close()
+	addr := freeUDPAddr(t)
+	if err := srv.ListenSearch(addr); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := pjlink.DiscoverBroadcast(context.Background(), addr, 300*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []pjlink.DiscoveredProjector{{Address: "127.0.0.1", MAC: "00:1a:2b:3c:4d:5e"}}
+	if len(found) != 1 || found[0] != want[0] {
+		t.Errorf("DiscoverBroadcast() = %+v, want %+v", found, want)
+	}
+}
+
+func TestDiscoverBroadcastWithoutAnswers(t *testing.T) {
+	found, err := pjlink.DiscoverBroadcast(context.Background(), freeUDPAddr(t), 100*time.Millisecond)
+	if err != nil || len(found) != 0 {
+		t.Errorf("DiscoverBroadcast() = %+v, %v, want nothing", found, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	if _, err := pjlink.DiscoverBroadcast(ctx, freeUDPAddr(t), time.Minute); err != context.Canceled {
+		t.Errorf("DiscoverBroadcast() after cancel = %v, want context.Canceled", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("DiscoverBroadcast() didn't stop when the context was cancelled")
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Class: 2, Name: "Hall A", Manufacturer: "ACME", Model: "PJ 9000", Info: "rev 2"})
+	defer srv.Close()
+
+	id, err := srv.Projector().Identify()
+	want := pjlink.Identity{Name: "Hall A", Manufacturer: "ACME", Model: "PJ 9000", Info: "rev 2", Class: 2}
+	if err != nil || id != want {
+		t.Errorf("Identify() = %+v, %v, want %+v", id, err, want)
+	}
+}
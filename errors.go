@@ -0,0 +1,166 @@
+package pjlink
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors reported by the Projector, match them with errors.Is
+var (
+	ErrUndefinedCommand = errors.New("undefined command")         // ERR1
+	ErrOutOfParameter   = errors.New("out of parameter")          // ERR2
+	ErrUnavailableTime  = errors.New("unavailable time")          // ERR3
+	ErrDeviceFailure    = errors.New("projector/display failure") // ERR4
+	ErrAuthentication   = errors.New("authentication failed")     // ERRA, usually a wrong password
+)
+
+// Errors raised on this side of the connection, match them with errors.Is
+var (
+	ErrInvalidRequest  = errors.New("invalid request")  // the request was not sent, see RequestError
+	ErrInvalidResponse = errors.New("invalid response") // the answer could not be decoded, see ResponseError
+	ErrNetwork         = errors.New("network error")    // the device could not be reached, see NetworkError
+	ErrCredentials     = errors.New("no credentials")   // the password could not be obtained, see CredentialError
+	ErrUnsupported     = errors.ErrUnsupported          // PJLink has no command for what was asked
+)
+
+// error codes of the PJLink spec and their errors
+var errorCodes = map[string]error{
+	"ERR1": ErrUndefinedCommand,
+	"ERR2": ErrOutOfParameter,
+	"ERR3": ErrUnavailableTime,
+	"ERR4": ErrDeviceFailure,
+	"ERRA": ErrAuthentication,
+}
+
+// ProjectorError is returned when the Projector answers with one of the error codes ERR1-ERR4 or ERRA
+type ProjectorError struct {
+	Address string // host:port of the Projector
+	Command string
+	Code    string // ERR1, ERR2, ERR3, ERR4 or ERRA
+}
+
+func (e *ProjectorError) Error() string {
+	return describe(e.Address, e.Command, e.Unwrap().Error()+" ("+e.Code+")")
+}
+
+func (e *ProjectorError) Unwrap() error {
+	if err, ok := errorCodes[e.Code]; ok {
+		return err
+	}
+	return ErrInvalidResponse
+}
+
+// RequestError is returned when a request is rejected before it is sent
+type RequestError struct {
+	Address string
+	Command string
+	Reason  string
+}
+
+func (e *RequestError) Error() string {
+	return describe(e.Address, e.Command, e.Reason)
+}
+
+func (e *RequestError) Unwrap() error {
+	return ErrInvalidRequest
+}
+
+// ResponseError is returned when the answer of the Projector can't be decoded
+type ResponseError struct {
+	Address  string
+	Command  string
+	Response string // raw response
+	Reason   string
+}
+
+func (e *ResponseError) Error() string {
+	return describe(e.Address, e.Command, e.Reason)
+}
+
+func (e *ResponseError) Unwrap() error {
+	return ErrInvalidResponse
+}
+
+// NetworkError is returned when connecting, sending or receiving fails
+type NetworkError struct {
+	Address string
+	Command string
+	Op      string // dial, greeting, write or read
+	Err     error
+}
+
+func (e *NetworkError) Error() string {
+	return describe(e.Address, e.Command, e.Op+": "+e.Err.Error())
+}
+
+// matches ErrNetwork as well as the underlying error, e.g. context.DeadlineExceeded
+func (e *NetworkError) Unwrap() []error {
+	return []error{ErrNetwork, e.Err}
+}
+
+// CredentialError is returned when the CredentialProvider of a Projector fails on an authentication challenge
+type CredentialError struct {
+	Address string
+	Command string
+	Err     error
+}
+
+func (e *CredentialError) Error() string {
+	return describe(e.Address, e.Command, "credentials: "+e.Err.Error())
+}
+
+// matches ErrCredentials as well as the underlying error
+func (e *CredentialError) Unwrap() []error {
+	return []error{ErrCredentials, e.Err}
+}
+
+func describe(address string, command string, msg string) string {
+	parts := []string{"pjlink"}
+	if address != "" {
+		parts = append(parts, address)
+	}
+	if command != "" {
+		parts = append(parts, command)
+	}
+	return strings.Join(parts, ": ") + ": " + msg
+}
+
+// fills in the address and command of the Projector on the errors of this package
+func (pr *PJProjector) annotate(err error, command string) error {
+	if err == nil {
+		return nil
+	}
+
+	address := pr.hostPort()
+	var (
+		projectorErr  *ProjectorError
+		requestErr    *RequestError
+		responseErr   *ResponseError
+		networkErr    *NetworkError
+		credentialErr *CredentialError
+	)
+	switch {
+	case errors.As(err, &projectorErr):
+		fill(&projectorErr.Address, address)
+		fill(&projectorErr.Command, command)
+	case errors.As(err, &requestErr):
+		fill(&requestErr.Address, address)
+		fill(&requestErr.Command, command)
+	case errors.As(err, &responseErr):
+		fill(&responseErr.Address, address)
+		fill(&responseErr.Command, command)
+	case errors.As(err, &networkErr):
+		fill(&networkErr.Address, address)
+		fill(&networkErr.Command, command)
+	case errors.As(err, &credentialErr):
+		fill(&credentialErr.Address, address)
+		fill(&credentialErr.Command, command)
+	}
+	return err
+}
+
+func fill(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
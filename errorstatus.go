@@ -0,0 +1,124 @@
+package pjlink
+
+import (
+	"context"
+)
+
+// ErrorLevel of a single component reported by ERST
+type ErrorLevel int
+
+const (
+	ErrorLevelOK ErrorLevel = iota
+	ErrorLevelWarning
+	ErrorLevelError
+)
+
+func (level ErrorLevel) String() string {
+	switch level {
+	case ErrorLevelOK:
+		return "ok"
+	case ErrorLevelWarning:
+		return "warning"
+	case ErrorLevelError:
+		return "error"
+	}
+	return "unknown"
+}
+
+func (level ErrorLevel) MarshalText() ([]byte, error) {
+	return []byte(level.String()), nil
+}
+
+// ErrorStatus as reported by ERST, one level per component
+type ErrorStatus struct {
+	Fan         ErrorLevel `json:"fan"`
+	Lamp        ErrorLevel `json:"lamp"`
+	Temperature ErrorLevel `json:"temperature"`
+	CoverOpen   ErrorLevel `json:"cover-open"`
+	Filter      ErrorLevel `json:"filter"`
+	Other       ErrorLevel `json:"other"`
+}
+
+// names of the components in the order of the ERST response
+var ErrorStatusComponents = []string{"fan", "lamp", "temperature", "cover-open", "filter", "other"}
+
+// levels in the order of ErrorStatusComponents
+func (status ErrorStatus) levels() []ErrorLevel {
+	return []ErrorLevel{status.Fan, status.Lamp, status.Temperature, status.CoverOpen, status.Filter, status.Other}
+}
+
+// Level of a component by its name in ErrorStatusComponents
+func (status ErrorStatus) Level(component string) (ErrorLevel, bool) {
+	for i, name := range ErrorStatusComponents {
+		if name == component {
+			return status.levels()[i], true
+		}
+	}
+	return ErrorLevelOK, false
+}
+
+func (status ErrorStatus) HasErrors() bool {
+	return len(status.Errors()) > 0
+}
+
+func (status ErrorStatus) HasWarnings() bool {
+	return len(status.Warnings()) > 0
+}
+
+// names of the components reporting an error
+func (status ErrorStatus) Errors() []string {
+	return status.components(ErrorLevelError)
+}
+
+// names of the components reporting a warning
+func (status ErrorStatus) Warnings() []string {
+	return status.components(ErrorLevelWarning)
+}
+
+func (status ErrorStatus) components(level ErrorLevel) []string {
+	var components []string
+	for i, l := range status.levels() {
+		if l == level {
+			components = append(components, ErrorStatusComponents[i])
+		}
+	}
+	return components
+}
+
+// decodes the six digit ERST response, each digit is 0 (ok), 1 (warning) or 2 (error).
+// Invalid responses are reported as *ResponseError.
+func ParseErrorStatus(raw string) (ErrorStatus, error) {
+	if len(raw) != len(ErrorStatusComponents) {
+		return ErrorStatus{}, &ResponseError{Command: "ERST", Response: raw, Reason: "Invalid error status: " + raw}
+	}
+
+	levels := make([]ErrorLevel, len(raw))
+	for i := 0; i < len(raw); i++ {
+		if raw[i] < '0' || raw[i] > '2' {
+			return ErrorStatus{}, &ResponseError{Command: "ERST", Response: raw, Reason: "Invalid error status: " + raw}
+		}
+		levels[i] = ErrorLevel(raw[i] - '0')
+	}
+
+	return ErrorStatus{
+		Fan:         levels[0],
+		Lamp:        levels[1],
+		Temperature: levels[2],
+		CoverOpen:   levels[3],
+		Filter:      levels[4],
+		Other:       levels[5],
+	}, nil
+}
+
+func (pr *PJProjector) ErrorStatus() (ErrorStatus, error) {
+	return pr.ErrorStatusContext(context.Background())
+}
+
+func (pr *PJProjector) ErrorStatusContext(ctx context.Context) (ErrorStatus, error) {
+	resp, err := pr.query(ctx, 1, "ERST", ErrorStatusRequests["query"])
+	if err != nil {
+		return ErrorStatus{}, err
+	}
+	status, err := ParseErrorStatus(resp.Response[0])
+	return status, pr.annotate(err, "ERST")
+}
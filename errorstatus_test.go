@@ -0,0 +1,24 @@
+package pjlink_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+func TestParseErrorStatus(t *testing.T) {
+	status, err := pjlink.ParseErrorStatus("012000")
+	want := pjlink.ErrorStatus{Lamp: pjlink.ErrorLevelWarning, Temperature: pjlink.ErrorLevelError}
+	if err != nil || status != want {
+		t.Errorf("ParseErrorStatus(012000) = %+v, %v, want %+v", status, err, want)
+	}
+
+	for _, raw := range []string{"", "00000", "0000000", "000300", "00000a"} {
+		_, err := pjlink.ParseErrorStatus(raw)
+		var respErr *pjlink.ResponseError
+		if !errors.As(err, &respErr) || !errors.Is(err, pjlink.ErrInvalidResponse) {
+			t.Errorf("ParseErrorStatus(%q) = %v, want a ResponseError", raw, err)
+		}
+	}
+}
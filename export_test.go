@@ -0,0 +1,11 @@
+package pjlink
+
+import "time"
+
+// SetSessionMaxIdle shortens how long a session may be idle before it is redialed, the
+// returned function restores the limit
+func SetSessionMaxIdle(idle time.Duration) (restore func()) {
+	previous := sessionMaxIdle
+	sessionMaxIdle = idle
+	return func() { sessionMaxIdle = previous }
+}
@@ -0,0 +1,179 @@
+// Package exporter provides a Prometheus collector for the health of PJLink projectors.
+//
+// Every scrape queries all projectors of a Fleet, so the Fleet's Parallelism, Timeout and
+// Retries apply. The Collector's Timeout limits the whole scrape:
+//
+//	fleet := pjlink.NewFleet()
+//	fleet.Add("hall-a", pjlink.NewProjector("10.0.0.5", "secret"))
+//	prometheus.MustRegister(exporter.New(fleet))
+package exporter
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	upDesc = prometheus.NewDesc("pjlink_up",
+		"Whether all queries of the last scrape succeeded.",
+		[]string{"projector"}, nil)
+	powerDesc = prometheus.NewDesc("pjlink_power_state",
+		"Power state of the projector: 0 off, 1 on, 2 cooling, 3 warm-up.",
+		[]string{"projector"}, nil)
+	lampHoursDesc = prometheus.NewDesc("pjlink_lamp_hours",
+		"Cumulative lighting hours of the lamp.",
+		[]string{"projector", "lamp"}, nil)
+	lampOnDesc = prometheus.NewDesc("pjlink_lamp_on",
+		"Whether the lamp is lit.",
+		[]string{"projector", "lamp"}, nil)
+	errorStatusDesc = prometheus.NewDesc("pjlink_error_status",
+		"Error status of a component: 0 ok, 1 warning, 2 error.",
+		[]string{"projector", "component"}, nil)
+	filterHoursDesc = prometheus.NewDesc("pjlink_filter_usage_hours",
+		"Usage time of the filter, only reported by Class 2 projectors.",
+		[]string{"projector"}, nil)
+	durationDesc = prometheus.NewDesc("pjlink_scrape_duration_seconds",
+		"Time the last scrape of the projector took.",
+		[]string{"projector"}, nil)
+)
+
+// limit of a scrape if the Collector has no Timeout, Prometheus gives up after 10s by default
+const defaultTimeout = 9 * time.Second
+
+// Collector is a prometheus.Collector that scrapes the projectors of a Fleet on every collection
+type Collector struct {
+	// limit of a whole scrape, keep it below the scrape_timeout of Prometheus. 9s if not set.
+	// Projectors that haven't answered by then are reported as down.
+	Timeout time.Duration
+
+	fleet    *pjlink.Fleet
+	failures *prometheus.CounterVec
+}
+
+// values read from a projector, nil for queries that failed
+type scrape struct {
+	power       *pjlink.PowerState
+	lamps       []pjlink.Lamp
+	errorStatus *pjlink.ErrorStatus
+	filterHours *int
+}
+
+func New(fleet *pjlink.Fleet) *Collector {
+	return &Collector{
+		fleet: fleet,
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pjlink_scrape_failures_total",
+			Help: "Scrapes of the projector in which at least one query failed.",
+		}, []string{"projector"}),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- upDesc
+	ch <- powerDesc
+	ch <- lampHoursDesc
+	ch <- lampOnDesc
+	ch <- errorStatusDesc
+	ch <- filterHoursDesc
+	ch <- durationDesc
+	c.failures.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	var mu sync.Mutex
+	scrapes := make(map[*pjlink.PJProjector]*scrape)
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	report := c.fleet.Run(ctx, func(ctx context.Context, pr *pjlink.PJProjector) error {
+		values, err := scrapeProjector(ctx, pr)
+		mu.Lock()
+		scrapes[pr] = values
+		mu.Unlock()
+		return err
+	})
+
+	for _, result := range report {
+		pr, ok := c.fleet.Projector(result.Name)
+		if !ok {
+			continue
+		}
+		up := 1.0
+		failures := c.failures.WithLabelValues(result.Name)
+		if result.Err != nil {
+			up = 0
+			failures.Inc()
+		}
+		ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, up, result.Name)
+		ch <- prometheus.MustNewConstMetric(durationDesc, prometheus.GaugeValue, result.Duration.Seconds(), result.Name)
+		if values := scrapes[pr]; values != nil {
+			values.collect(ch, result.Name)
+		}
+	}
+	c.failures.Collect(ch)
+}
+
+// reads everything that is exported, values that could be read are returned along with the errors
+func scrapeProjector(ctx context.Context, pr *pjlink.PJProjector) (*scrape, error) {
+	values := &scrape{}
+	var errs []error
+
+	if power, err := pr.PowerContext(ctx); err == nil {
+		values.power = &power
+	} else {
+		errs = append(errs, err)
+	}
+	if lamps, err := pr.LampsContext(ctx); err == nil {
+		values.lamps = lamps
+	} else {
+		errs = append(errs, err)
+	}
+	if status, err := pr.ErrorStatusContext(ctx); err == nil {
+		values.errorStatus = &status
+	} else {
+		errs = append(errs, err)
+	}
+	if pr.Class >= 2 {
+		if hours, err := pr.GetFilterUsageContext(ctx); err == nil {
+			values.filterHours = &hours
+		} else {
+			errs = append(errs, err)
+		}
+	}
+	return values, errors.Join(errs...)
+}
+
+func (values *scrape) collect(ch chan<- prometheus.Metric, name string) {
+	if values.power != nil {
+		ch <- prometheus.MustNewConstMetric(powerDesc, prometheus.GaugeValue, float64(*values.power), name)
+	}
+	for i, lamp := range values.lamps {
+		lampNumber := strconv.Itoa(i + 1)
+		on := 0.0
+		if lamp.On {
+			on = 1
+		}
+		ch <- prometheus.MustNewConstMetric(lampHoursDesc, prometheus.GaugeValue, float64(lamp.Hours), name, lampNumber)
+		ch <- prometheus.MustNewConstMetric(lampOnDesc, prometheus.GaugeValue, on, name, lampNumber)
+	}
+	if values.errorStatus != nil {
+		for _, component := range pjlink.ErrorStatusComponents {
+			level, _ := values.errorStatus.Level(component)
+			ch <- prometheus.MustNewConstMetric(errorStatusDesc, prometheus.GaugeValue, float64(level), name, component)
+		}
+	}
+	if values.filterHours != nil {
+		ch <- prometheus.MustNewConstMetric(filterHoursDesc, prometheus.GaugeValue, float64(*values.filterHours), name)
+	}
+}
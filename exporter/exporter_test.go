@@ -0,0 +1,117 @@
+package exporter_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/exporter"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	hall := pjlinktest.NewServer(pjlinktest.Profile{Class: 2, Lamps: 2, LampHours: 1200})
+	defer hall.Close()
+	hall.SetPower(pjlink.PowerOn)
+	hall.SetErrorStatus(pjlink.ErrorStatus{Lamp: pjlink.ErrorLevelWarning, Filter: pjlink.ErrorLevelError})
+	hall.SetFilterHours(560)
+
+	broken := pjlinktest.NewServer(pjlinktest.Profile{})
+	defer broken.Close()
+	broken.SetFailure(true)
+
+	fleet := pjlink.NewFleet()
+	fleet.Add("hall-a", hall.Projector())
+	fleet.Add("hall-b", broken.Projector())
+	collector := exporter.New(fleet)
+
+	expected := `
+# HELP pjlink_up Whether all queries of the last scrape succeeded.
+# TYPE pjlink_up gauge
+pjlink_up{projector="hall-a"} 1
+pjlink_up{projector="hall-b"} 0
+# HELP pjlink_power_state Power state of the projector: 0 off, 1 on, 2 cooling, 3 warm-up.
+# TYPE pjlink_power_state gauge
+pjlink_power_state{projector="hall-a"} 1
+# HELP pjlink_lamp_hours Cumulative lighting hours of the lamp.
+# TYPE pjlink_lamp_hours gauge
+pjlink_lamp_hours{lamp="1",projector="hall-a"} 1200
+pjlink_lamp_hours{lamp="2",projector="hall-a"} 1200
+# HELP pjlink_lamp_on Whether the lamp is lit.
+# TYPE pjlink_lamp_on gauge
+pjlink_lamp_on{lamp="1",projector="hall-a"} 1
+pjlink_lamp_on{lamp="2",projector="hall-a"} 1
+# HELP pjlink_error_status Error status of a component: 0 ok, 1 warning, 2 error.
+# TYPE pjlink_error_status gauge
+pjlink_error_status{component="cover-open",projector="hall-a"} 0
+pjlink_error_status{component="fan",projector="hall-a"} 0
+pjlink_error_status{component="filter",projector="hall-a"} 2
+pjlink_error_status{component="lamp",projector="hall-a"} 1
+pjlink_error_status{component="other",projector="hall-a"} 0
+pjlink_error_status{component="temperature",projector="hall-a"} 0
+# HELP pjlink_filter_usage_hours Usage time of the filter, only reported by Class 2 projectors.
+# TYPE pjlink_filter_usage_hours gauge
+pjlink_filter_usage_hours{projector="hall-a"} 560
+# HELP pjlink_scrape_failures_total Scrapes of the projector in which at least one query failed.
+# TYPE pjlink_scrape_failures_total counter
+pjlink_scrape_failures_total{projector="hall-a"} 0
+pjlink_scrape_failures_total{projector="hall-b"} 1
+`
+	metrics := []string{"pjlink_up", "pjlink_power_state", "pjlink_lamp_hours", "pjlink_lamp_on", "pjlink_error_status", "pjlink_filter_usage_hours", "pjlink_scrape_failures_total"}
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), metrics...); err != nil {
+		t.Error(err)
+	}
+
+	// every scrape of a failing projector is counted
+	failures := `
+# HELP pjlink_scrape_failures_total Scrapes of the projector in which at least one query failed.
+# TYPE pjlink_scrape_failures_total counter
+pjlink_scrape_failures_total{projector="hall-a"} 0
+pjlink_scrape_failures_total{projector="hall-b"} 2
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(failures), "pjlink_scrape_failures_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollectorTimeout(t *testing.T) {
+	// accepts connections but never greets
+	silent, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+	go func() {
+		for {
+			conn, err := silent.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(silent.Addr().String())
+	pr := pjlink.NewProjector(host, "")
+	pr.Port = port
+	fleet := pjlink.NewFleet()
+	fleet.Add("silent", pr)
+	collector := exporter.New(fleet)
+	collector.Timeout = 200 * time.Millisecond
+
+	start := time.Now()
+	expected := `
+# HELP pjlink_up Whether all queries of the last scrape succeeded.
+# TYPE pjlink_up gauge
+pjlink_up{projector="silent"} 0
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "pjlink_up"); err != nil {
+		t.Error(err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("scrape took %v, want it to stop after the timeout", elapsed)
+	}
+}
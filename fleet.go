@@ -0,0 +1,227 @@
+package pjlink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// devices a Fleet handles at once if Parallelism is not set
+const defaultParallelism = 8
+
+// Operation is run by a Fleet against each of its projectors, e.g.
+//
+//	func(ctx context.Context, pr *PJProjector) error { return pr.TurnOffContext(ctx) }
+type Operation func(ctx context.Context, pr *PJProjector) error
+
+// Fleet holds many projectors by name and runs operations across them concurrently.
+// A Fleet is safe for concurrent use, set its fields before running operations.
+type Fleet struct {
+	Parallelism int           // projectors handled at once, 8 if not set
+	Timeout     time.Duration // limit per projector and attempt, none if not set
+	Retries     int           // further attempts after a failure that may go away, e.g. ERR3 or a network error
+	RetryDelay  time.Duration // pause between attempts
+
+	mu      sync.RWMutex
+	members map[string]*fleetMember
+}
+
+type fleetMember struct {
+	projector *PJProjector
+	tags      map[string]bool
+}
+
+// FleetResult is the outcome of an operation on a single projector
+type FleetResult struct {
+	Name     string        `json:"name"`
+	Address  string        `json:"address"`
+	Err      error         `json:"-"`
+	Attempts int           `json:"attempts"`
+	Duration time.Duration `json:"duration"`
+}
+
+// MarshalJSON adds the message of Err as "error", so failed projectors stand out in a JSON report
+func (result FleetResult) MarshalJSON() ([]byte, error) {
+	type plain FleetResult // without this method
+	errorMessage := ""
+	if result.Err != nil {
+		errorMessage = result.Err.Error()
+	}
+	return json.Marshal(struct {
+		plain
+		Error string `json:"error,omitempty"`
+	}{plain(result), errorMessage})
+}
+
+// FleetReport holds one result per projector, sorted by name
+type FleetReport []FleetResult
+
+func NewFleet() *Fleet {
+	return &Fleet{members: make(map[string]*fleetMember)}
+}
+
+// Add adds or replaces the projector called name, tags put it into groups such as a building
+func (f *Fleet) Add(name string, pr *PJProjector, tags ...string) {
+	member := &fleetMember{projector: pr, tags: make(map[string]bool)}
+	for _, tag := range tags {
+		member.tags[tag] = true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.members[name] = member
+}
+
+func (f *Fleet) Remove(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.members, name)
+}
+
+func (f *Fleet) Projector(name string) (*PJProjector, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	member, ok := f.members[name]
+	if !ok {
+		return nil, false
+	}
+	return member.projector, true
+}
+
+// Names lists all projectors sorted by name
+func (f *Fleet) Names() []string {
+	return f.names(func(*fleetMember) bool { return true })
+}
+
+// Tagged lists the projectors carrying tag sorted by name
+func (f *Fleet) Tagged(tag string) []string {
+	return f.names(func(member *fleetMember) bool { return member.tags[tag] })
+}
+
+func (f *Fleet) names(match func(*fleetMember) bool) []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	names := make([]string, 0, len(f.members))
+	for name, member := range f.members {
+		if match(member) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs op against the named projectors, or all of them if no names are given
+func (f *Fleet) Run(ctx context.Context, op Operation, names ...string) FleetReport {
+	if len(names) == 0 {
+		names = f.Names()
+	}
+
+	parallelism := f.Parallelism
+	if parallelism <= 0 {
+		parallelism = defaultParallelism
+	}
+	slots := make(chan struct{}, parallelism)
+
+	report := make(FleetReport, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		report[i].Name = name
+
+		pr, ok := f.Projector(name)
+		if !ok {
+			report[i].Err = &RequestError{Reason: "No projector called " + name + " in the fleet"}
+			continue
+		}
+		report[i].Address = pr.hostPort()
+
+		wg.Add(1)
+		go func(result *FleetResult, pr *PJProjector) {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				result.Err = ctx.Err()
+				return
+			}
+			f.runOne(ctx, op, pr, result)
+		}(&report[i], pr)
+	}
+	wg.Wait()
+
+	sort.Slice(report, func(i, j int) bool { return report[i].Name < report[j].Name })
+	return report
+}
+
+// RunTagged runs op against the projectors carrying tag
+func (f *Fleet) RunTagged(ctx context.Context, tag string, op Operation) FleetReport {
+	names := f.Tagged(tag)
+	if len(names) == 0 {
+		return FleetReport{}
+	}
+	return f.Run(ctx, op, names...)
+}
+
+func (f *Fleet) runOne(ctx context.Context, op Operation, pr *PJProjector, result *FleetResult) {
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start) }()
+
+	for {
+		result.Attempts++
+		result.Err = f.attempt(ctx, op, pr)
+		if result.Err == nil || result.Attempts > f.Retries || !retryable(result.Err) {
+			return
+		}
+
+		select {
+		case <-time.After(f.RetryDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (f *Fleet) attempt(ctx context.Context, op Operation, pr *PJProjector) error {
+	if f.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.Timeout)
+		defer cancel()
+	}
+	return op(ctx, pr)
+}
+
+// errors which won't go away by asking again
+func retryable(err error) bool {
+	for _, permanent := range []error{ErrInvalidRequest, ErrUndefinedCommand, ErrOutOfParameter, ErrAuthentication, ErrCredentials, context.Canceled} {
+		if errors.Is(err, permanent) {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the results with an error
+func (report FleetReport) Failed() FleetReport {
+	failed := make(FleetReport, 0)
+	for _, result := range report {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err joins the errors of all failed projectors, nil if all succeeded
+func (report FleetReport) Err() error {
+	errs := make([]error, 0)
+	for _, result := range report.Failed() {
+		errs = append(errs, result.Err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,32 @@
+package pjlink
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFleetReportJSON(t *testing.T) {
+	report := FleetReport{
+		{Name: "hall-a", Address: "10.0.0.5:4352", Attempts: 1},
+		{Name: "hall-b", Address: "10.0.0.6:4352", Attempts: 3, Err: &ProjectorError{Address: "10.0.0.6:4352", Command: "POWR", Code: "ERR3"}},
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded[0]["error"]; ok {
+		t.Errorf("successful result has an error: %s", data)
+	}
+	if message, _ := decoded[1]["error"].(string); !strings.Contains(message, "ERR3") {
+		t.Errorf("failed result has error %q, want the ERR3 message", message)
+	}
+	if decoded[1]["name"] != "hall-b" || decoded[1]["attempts"] != float64(3) {
+		t.Errorf("fields of the failed result are missing: %s", data)
+	}
+}
@@ -0,0 +1,194 @@
+package pjlink
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// InputType is the source type of an input, the first digit of an INPT parameter
+type InputType int
+
+const (
+	InputRGB InputType = iota + 1
+	InputVideo
+	InputDigital
+	InputStorage
+	InputNetwork
+	InputInternal // Class 2 only
+)
+
+func (t InputType) String() string {
+	switch t {
+	case InputRGB:
+		return "rgb"
+	case InputVideo:
+		return "video"
+	case InputDigital:
+		return "digital"
+	case InputStorage:
+		return "storage"
+	case InputNetwork:
+		return "network"
+	case InputInternal:
+		return "internal"
+	}
+	return "unknown"
+}
+
+// Input is a source of the Projector, e.g. Input{InputDigital, 1} is "31".
+// Class 1 knows the indexes 1-9, Class 2 adds 10-35 for the letters A-Z.
+type Input struct {
+	Type  InputType `json:"type"`
+	Index int       `json:"index"`
+}
+
+const inputIndexes = "123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// parses the two character wire format given by a caller, e.g. "31" or "3A"
+func ParseInput(raw string) (Input, error) {
+	in, ok := parseInput(raw)
+	if !ok {
+		return Input{}, &RequestError{Command: "INPT", Reason: "Invalid input: " + raw}
+	}
+	return in, nil
+}
+
+// decodes an input the Projector answered command (INPT or INST) with
+func interpretInputResponse(command string, raw string) (Input, error) {
+	in, ok := parseInput(raw)
+	if !ok {
+		return Input{}, &ResponseError{Command: command, Response: raw, Reason: "Invalid input: " + raw}
+	}
+	return in, nil
+}
+
+func parseInput(raw string) (Input, bool) {
+	if !isClass2Input(raw) {
+		return Input{}, false
+	}
+	return Input{
+		Type:  InputType(raw[0] - '0'),
+		Index: strings.IndexByte(inputIndexes, raw[1]) + 1,
+	}, true
+}
+
+// parses the human readable name as used in InputRequests, e.g. "digital1" or "digitalA"
+func ParseHumanInput(name string) (Input, error) {
+	if raw, ok := InputRequests[name]; ok && raw != "?" {
+		return ParseInput(raw)
+	}
+	for t := InputRGB; t <= InputInternal; t++ {
+		if index, found := strings.CutPrefix(name, t.String()); found && len(index) == 1 {
+			return ParseInput(strconv.Itoa(int(t)) + strings.ToUpper(index))
+		}
+	}
+	return Input{}, &RequestError{Command: "INPT", Reason: "Invalid input: " + name}
+}
+
+// Wire format of the input, e.g. "31"
+func (in Input) Raw() string {
+	if in.Index < 1 || in.Index > len(inputIndexes) {
+		return strconv.Itoa(int(in.Type)) + "?"
+	}
+	return strconv.Itoa(int(in.Type)) + inputIndexes[in.Index-1:in.Index]
+}
+
+// Human readable name of the input, e.g. "digital1"
+func (in Input) String() string {
+	if name, ok := RawToHumanInputs[in.Raw()]; ok {
+		return name
+	}
+	return in.Type.String() + in.Raw()[1:]
+}
+
+func (in Input) valid() bool {
+	return isClass2Input(in.Raw())
+}
+
+// the PJLink class needed to select the input
+func (in Input) class() int {
+	if in.Type == InputInternal || in.Index > 9 {
+		return 2
+	}
+	return 1
+}
+
+// INPT, INST and AVMT are sent as Class 2 if the Projector supports it, so their Class 2 parameters
+// and answers are used, such as the inputs 6x and 3A
+func (pr *PJProjector) extendedClass() int {
+	if pr.Class >= 2 {
+		return 2
+	}
+	return 1
+}
+
+func (pr *PJProjector) GetInput() (Input, error) {
+	return pr.GetInputContext(context.Background())
+}
+
+func (pr *PJProjector) GetInputContext(ctx context.Context) (Input, error) {
+	resp, err := pr.query(ctx, pr.extendedClass(), "INPT", "?")
+	if err != nil {
+		return Input{}, err
+	}
+	in, err := interpretInputResponse("INPT", resp.Response[0])
+	return in, pr.annotate(err, "INPT")
+}
+
+// Selects the input, after checking it against the inputs the Projector reports with INST
+func (pr *PJProjector) SetInput(in Input) error {
+	return pr.SetInputContext(context.Background(), in)
+}
+
+func (pr *PJProjector) SetInputContext(ctx context.Context, in Input) error {
+	if !in.valid() {
+		return pr.annotate(&RequestError{Reason: "Invalid input: " + in.Raw()}, "INPT")
+	}
+
+	inputs, err := pr.InputsContext(ctx)
+	if err != nil {
+		return err
+	}
+	available := false
+	for _, input := range inputs {
+		if input == in {
+			available = true
+			break
+		}
+	}
+	if !available {
+		return pr.annotate(&RequestError{Reason: "Input " + in.String() + " is not available on this Projector"}, "INPT")
+	}
+
+	return pr.execute(ctx, in.class(), "INPT", in.Raw())
+}
+
+// Lists the inputs of the Projector (INST)
+func (pr *PJProjector) Inputs() ([]Input, error) {
+	return pr.InputsContext(context.Background())
+}
+
+func (pr *PJProjector) InputsContext(ctx context.Context) ([]Input, error) {
+	resp, err := pr.query(ctx, pr.extendedClass(), "INST", "?")
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := interpretInputListInputs(resp.Response)
+	return inputs, pr.annotate(err, "INST")
+}
+
+func interpretInputListInputs(tokens []string) ([]Input, error) {
+	inputs := make([]Input, 0, len(tokens))
+	for _, token := range tokens {
+		if token == "" {
+			continue
+		}
+		input, ok := parseInput(token)
+		if !ok {
+			return nil, &ResponseError{Command: "INST", Response: strings.Join(tokens, " "), Reason: "Invalid input: " + token}
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
@@ -0,0 +1,48 @@
+package pjlink
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Input
+		ok   bool
+	}{
+		{"11", Input{InputRGB, 1}, true},
+		{"31", Input{InputDigital, 1}, true},
+		{"3A", Input{InputDigital, 10}, true},
+		{"6Z", Input{InputInternal, 35}, true},
+		{"30", Input{}, false},
+		{"71", Input{}, false},
+		{"3a", Input{}, false},
+		{"311", Input{}, false},
+	}
+	for _, test := range tests {
+		got, err := ParseInput(test.raw)
+		if test.ok && (err != nil || got != test.want) {
+			t.Errorf("ParseInput(%s) = %+v, %v, want %+v", test.raw, got, err, test.want)
+		}
+		if !test.ok && !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("ParseInput(%s) = %v, want invalid request", test.raw, err)
+		}
+	}
+}
+
+func TestInvalidInputResponse(t *testing.T) {
+	pr := scriptedDevice(t, map[string]string{
+		"%1INPT ?": "%1INPT=99",
+		"%1INST ?": "%1INST=11 31 XX",
+	})
+	pr.Class = 1
+
+	var responseErr *ResponseError
+	if _, err := pr.GetInput(); !errors.Is(err, ErrInvalidResponse) || !errors.As(err, &responseErr) || responseErr.Response != "99" {
+		t.Errorf("GetInput() = %v, want a ResponseError for 99", err)
+	}
+	if _, err := pr.Inputs(); !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("Inputs() = %v, want invalid response", err)
+	}
+}
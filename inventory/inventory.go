@@ -0,0 +1,197 @@
+// Package inventory reads a file of named projectors, so tools can address them as
+// "room-101" or by a tag such as "lecture-halls" instead of by address and password.
+//
+// YAML (.yaml, .yml, also accepts JSON) and TOML (.toml) are supported:
+//
+//	password: env:PJLINK_PASSWORD        # for projectors without their own
+//	projectors:
+//	  room-101:
+//	    address: 10.0.1.101
+//	    password: file:/etc/pjlink/room-101
+//	    class: 2
+//	    tags: [lecture-halls, building-a]
+//	  room-102:
+//	    address: 10.0.1.102
+//	    port: "14352"
+//	    tags: [lecture-halls]
+//
+// Passwords are references, "env:NAME" reads the environment variable NAME, "file:PATH" the
+// first line of the file at PATH and "hosts:PATH" the line of the projector's address in a
+// key-per-host file, see pjlink.HostFileCredentials. Files must only be readable by their owner.
+// Anything else is the password itself. References are resolved on each authentication
+// challenge, not when the inventory is loaded.
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Inventory is a set of named projectors
+type Inventory struct {
+	Password   string               `yaml:"password" toml:"password"` // reference used by projectors without their own
+	Projectors map[string]Projector `yaml:"projectors" toml:"projectors"`
+}
+
+// Projector is the entry of a single projector
+type Projector struct {
+	Address  string   `yaml:"address" toml:"address"`
+	Port     string   `yaml:"port" toml:"port"`         // 4352 if not set
+	Password string   `yaml:"password" toml:"password"` // reference, see the package documentation
+	Class    int      `yaml:"class" toml:"class"`
+	Tags     []string `yaml:"tags" toml:"tags"`
+}
+
+// Load reads an inventory file, the format follows from the extension
+func Load(path string) (*Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	inv, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("inventory %s: %w", path, err)
+	}
+	return inv, nil
+}
+
+// Parse reads an inventory in format "yaml", "yml", "json" or "toml"
+func Parse(data []byte, format string) (*Inventory, error) {
+	inv := &Inventory{}
+	var err error
+	switch strings.ToLower(format) {
+	case "yaml", "yml", "json":
+		err = yaml.Unmarshal(data, inv)
+	case "toml":
+		err = toml.Unmarshal(data, inv)
+	default:
+		return nil, errors.New("unknown format " + format + ", expected yaml or toml")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for name, entry := range inv.Projectors {
+		if entry.Address == "" {
+			return nil, errors.New("projector " + name + " has no address")
+		}
+	}
+	return inv, nil
+}
+
+// Names lists all projectors sorted by name
+func (inv *Inventory) Names() []string {
+	names := make([]string, 0, len(inv.Projectors))
+	for name := range inv.Projectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tagged lists the projectors carrying tag sorted by name
+func (inv *Inventory) Tagged(tag string) []string {
+	names := make([]string, 0)
+	for _, name := range inv.Names() {
+		for _, t := range inv.Projectors[name].Tags {
+			if t == tag {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// Select resolves names and tags to the sorted names of the projectors, without duplicates.
+// Unknown names and tags without projectors are errors.
+func (inv *Inventory) Select(targets []string, groups []string) ([]string, error) {
+	selected := make(map[string]bool)
+	for _, name := range targets {
+		if _, ok := inv.Projectors[name]; !ok {
+			return nil, errors.New("no projector called " + name + " in the inventory")
+		}
+		selected[name] = true
+	}
+	for _, group := range groups {
+		names := inv.Tagged(group)
+		if len(names) == 0 {
+			return nil, errors.New("no projector tagged " + group + " in the inventory")
+		}
+		for _, name := range names {
+			selected[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Projector builds a client for the projector called name
+func (inv *Inventory) Projector(name string) (*pjlink.PJProjector, error) {
+	entry, ok := inv.Projectors[name]
+	if !ok {
+		return nil, errors.New("no projector called " + name + " in the inventory")
+	}
+
+	reference := entry.Password
+	if reference == "" {
+		reference = inv.Password
+	}
+
+	pr := pjlink.NewProjector(entry.Address, "")
+	if pr.Credentials = Credentials(reference); pr.Credentials == nil {
+		pr.Password = reference
+	}
+	if entry.Port != "" {
+		pr.Port = entry.Port
+	}
+	pr.Class = entry.Class
+	return pr, nil
+}
+
+// Fleet builds a Fleet of the named projectors with their tags, all of them if no names are given
+func (inv *Inventory) Fleet(names ...string) (*pjlink.Fleet, error) {
+	if len(names) == 0 {
+		names = inv.Names()
+	}
+
+	fleet := pjlink.NewFleet()
+	for _, name := range names {
+		pr, err := inv.Projector(name)
+		if err != nil {
+			return nil, err
+		}
+		fleet.Add(name, pr, inv.Projectors[name].Tags...)
+	}
+	return fleet, nil
+}
+
+// Credentials returns the provider a password reference points to, nil if the reference is
+// the password itself
+func Credentials(reference string) pjlink.CredentialProvider {
+	if name, ok := strings.CutPrefix(reference, "env:"); ok {
+		return pjlink.EnvCredentials(name)
+	}
+	if path, ok := strings.CutPrefix(reference, "file:"); ok {
+		return pjlink.FileCredentials(path)
+	}
+	if path, ok := strings.CutPrefix(reference, "hosts:"); ok {
+		return pjlink.HostFileCredentials(path)
+	}
+	return nil
+}
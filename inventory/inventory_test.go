@@ -0,0 +1,230 @@
+package inventory_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/LightInstruments/pjlink/inventory"
+)
+
+const yamlInventory = `
+password: env:PJLINK_TEST_PASSWORD
+projectors:
+  room-101:
+    address: 10.0.1.101
+    password: secret
+    class: 2
+    tags: [lecture-halls, building-a]
+  room-102:
+    address: 10.0.1.102
+    port: "14352"
+    tags: [lecture-halls]
+  lobby:
+    address: 10.0.2.1
+`
+
+const tomlInventory = `
+password = "env:PJLINK_TEST_PASSWORD"
+
+[projectors.room-101]
+address = "10.0.1.101"
+password = "secret"
+class = 2
+tags = ["lecture-halls", "building-a"]
+
+[projectors.room-102]
+address = "10.0.1.102"
+port = "14352"
+tags = ["lecture-halls"]
+
+[projectors.lobby]
+address = "10.0.2.1"
+`
+
+const jsonInventory = `{
+  "password": "env:PJLINK_TEST_PASSWORD",
+  "projectors": {
+    "room-101": {"address": "10.0.1.101", "password": "secret", "class": 2, "tags": ["lecture-halls", "building-a"]},
+    "room-102": {"address": "10.0.1.102", "port": "14352", "tags": ["lecture-halls"]},
+    "lobby": {"address": "10.0.2.1"}
+  }
+}`
+
+var parsed = &inventory.Inventory{
+	Password: "env:PJLINK_TEST_PASSWORD",
+	Projectors: map[string]inventory.Projector{
+		"room-101": {Address: "10.0.1.101", Password: "secret", Class: 2, Tags: []string{"lecture-halls", "building-a"}},
+		"room-102": {Address: "10.0.1.102", Port: "14352", Tags: []string{"lecture-halls"}},
+		"lobby":    {Address: "10.0.2.1"},
+	},
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		format string
+		data   string
+		want   *inventory.Inventory
+	}{
+		{"yaml", yamlInventory, parsed},
+		{"YML", yamlInventory, parsed},
+		{"toml", tomlInventory, parsed},
+		{"json", jsonInventory, parsed},
+		{"yaml", "", &inventory.Inventory{}},
+		{"yaml", "projectors:\n  lobby:\n    port: \"4352\"\n", nil},
+		{"toml", "[projectors.lobby]\nport = \"4352\"\n", nil},
+		{"yaml", "projectors: [", nil},
+		{"toml", "projectors = [", nil},
+		{"ini", "[projectors]", nil},
+	}
+	for _, test := range tests {
+		inv, err := inventory.Parse([]byte(test.data), test.format)
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("Parse(%q, %s) = %+v, want an error", test.data, test.format, inv)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q, %s): %v", test.data, test.format, err)
+			continue
+		}
+		if !reflect.DeepEqual(inv, test.want) {
+			t.Errorf("Parse(%q, %s) = %+v, want %+v", test.data, test.format, inv, test.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{"projectors.yaml": yamlInventory, "projectors.toml": tomlInventory} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		inv, err := inventory.Load(path)
+		if err != nil || !reflect.DeepEqual(inv, parsed) {
+			t.Errorf("Load(%s) = %+v, %v", name, inv, err)
+		}
+	}
+	if _, err := inventory.Load(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Load() of a missing file succeeded")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		targets []string
+		groups  []string
+		want    []string
+	}{
+		{nil, nil, []string{}},
+		{[]string{"lobby"}, nil, []string{"lobby"}},
+		{nil, []string{"lecture-halls"}, []string{"room-101", "room-102"}},
+		{nil, []string{"building-a"}, []string{"room-101"}},
+		{[]string{"room-101", "lobby"}, []string{"lecture-halls"}, []string{"lobby", "room-101", "room-102"}},
+		{nil, []string{"building-a", "lecture-halls"}, []string{"room-101", "room-102"}},
+		{[]string{"room-999"}, nil, nil},
+		{nil, []string{"basement"}, nil},
+	}
+	for _, test := range tests {
+		names, err := parsed.Select(test.targets, test.groups)
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("Select(%q, %q) = %q, want an error", test.targets, test.groups, names)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(names, test.want) {
+			t.Errorf("Select(%q, %q) = %q, %v, want %q", test.targets, test.groups, names, err, test.want)
+		}
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	t.Setenv("PJLINK_TEST_PASSWORD", "from-env")
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "room-101")
+	if err := os.WriteFile(secret, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	hosts := filepath.Join(dir, "hosts")
+	if err := os.WriteFile(hosts, []byte("10.0.1.101 from-hosts\n* fallback\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		reference string
+		address   string
+		want      string
+	}{
+		{"env:PJLINK_TEST_PASSWORD", "10.0.1.101", "from-env"},
+		{"file:" + secret, "10.0.1.101", "from-file"},
+		{"hosts:" + hosts, "10.0.1.101", "from-hosts"},
+		{"hosts:" + hosts, "10.0.1.102", "fallback"},
+		{"env:PJLINK_TEST_UNSET", "10.0.1.101", ""},
+		{"file:" + filepath.Join(dir, "missing"), "10.0.1.101", ""},
+	}
+	for _, test := range tests {
+		provider := inventory.Credentials(test.reference)
+		if provider == nil {
+			t.Errorf("Credentials(%q) = nil", test.reference)
+			continue
+		}
+		password, err := provider.Password(context.Background(), test.address)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("Credentials(%q) gave %q for %s, want an error", test.reference, password, test.address)
+			}
+			continue
+		}
+		if err != nil || password != test.want {
+			t.Errorf("Credentials(%q) gave %q, %v for %s, want %q", test.reference, password, err, test.address, test.want)
+		}
+	}
+
+	for _, plain := range []string{"", "secret", "environment:X", "ENV:X"} {
+		if provider := inventory.Credentials(plain); provider != nil {
+			t.Errorf("Credentials(%q) = %v, want nil for a plain password", plain, provider)
+		}
+	}
+}
+
+func TestProjector(t *testing.T) {
+	t.Setenv("PJLINK_TEST_PASSWORD", "from-env")
+
+	// a plain password is set directly
+	pr, err := parsed.Projector("room-101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Address != "10.0.1.101" || pr.Port != "4352" || pr.Password != "secret" || pr.Credentials != nil || pr.Class != 2 {
+		t.Errorf("room-101 = %+v", pr)
+	}
+
+	// projectors without a password use the inventory's reference
+	pr, err = parsed.Projector("room-102")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Port != "14352" || pr.Password != "" || pr.Credentials == nil {
+		t.Fatalf("room-102 = %+v", pr)
+	}
+	if password, err := pr.Credentials.Password(context.Background(), pr.Address); err != nil || password != "from-env" {
+		t.Errorf("room-102 password = %q, %v, want the one of the environment", password, err)
+	}
+
+	if _, err := parsed.Projector("room-999"); err == nil {
+		t.Error("Projector() of an unknown name succeeded")
+	}
+
+	fleet, err := parsed.Fleet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := fleet.Names(); !reflect.DeepEqual(names, []string{"lobby", "room-101", "room-102"}) {
+		t.Errorf("Fleet().Names() = %q", names)
+	}
+}
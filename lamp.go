@@ -0,0 +1,85 @@
+package pjlink
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// PJLink reports up to 8 lamps with up to 5 digits of hours each
+const (
+	maxLamps     = 8
+	maxLampHours = 5
+)
+
+// Lamp as reported by LAMP
+type Lamp struct {
+	Hours int  `json:"hours"`
+	On    bool `json:"on"`
+}
+
+// LampResponseError is the ResponseError of a LAMP response that could not be decoded
+type LampResponseError struct {
+	ResponseError
+	Lamp int // 1-based lamp the problem was found at, 0 if it concerns the whole response
+}
+
+func (e *LampResponseError) Unwrap() error {
+	return &e.ResponseError
+}
+
+func lampResponseError(tokens []string, lamp int, reason string) *LampResponseError {
+	raw := strings.Join(tokens, " ")
+	msg := "Invalid lamp response \"" + raw + "\": "
+	if lamp > 0 {
+		msg += "lamp " + strconv.Itoa(lamp) + ": "
+	}
+	return &LampResponseError{ResponseError: ResponseError{Command: "LAMP", Response: raw, Reason: msg + reason}, Lamp: lamp}
+}
+
+// decodes the "<hours> <on> <hours> <on> ..." pairs of a LAMP response, invalid ones are
+// reported as *LampResponseError
+func ParseLamps(tokens []string) ([]Lamp, error) {
+	if len(tokens)%2 != 0 {
+		return nil, lampResponseError(tokens, 0, "odd number of values, expected hours and state per lamp")
+	}
+	count := len(tokens) / 2
+	if count < 1 || count > maxLamps {
+		return nil, lampResponseError(tokens, 0, "expected 1 to "+strconv.Itoa(maxLamps)+" lamps, got "+strconv.Itoa(count))
+	}
+
+	lamps := make([]Lamp, count)
+	for i := range lamps {
+		rawHours, rawState := tokens[2*i], tokens[2*i+1]
+
+		if len(rawHours) < 1 || len(rawHours) > maxLampHours {
+			return nil, lampResponseError(tokens, i+1, "hours must have 1 to "+strconv.Itoa(maxLampHours)+" digits, got \""+rawHours+"\"")
+		}
+		hours, err := strconv.Atoi(rawHours)
+		if err != nil || hours < 0 {
+			return nil, lampResponseError(tokens, i+1, "hours are not a number: \""+rawHours+"\"")
+		}
+
+		state, ok := LampStateResponses[rawState]
+		if !ok {
+			return nil, lampResponseError(tokens, i+1, "state must be 0 or 1, got \""+rawState+"\"")
+		}
+
+		lamps[i] = Lamp{Hours: hours, On: state == "on"}
+	}
+	return lamps, nil
+}
+
+// Lists the lamps of the Projector with their usage hours
+func (pr *PJProjector) Lamps() ([]Lamp, error) {
+	return pr.LampsContext(context.Background())
+}
+
+func (pr *PJProjector) LampsContext(ctx context.Context) ([]Lamp, error) {
+	resp, err := pr.query(ctx, 1, "LAMP", LampRequests["query"])
+	if err != nil {
+		return nil, err
+	}
+	lamps, err := ParseLamps(resp.Response)
+	return lamps, pr.annotate(err, "LAMP")
+}
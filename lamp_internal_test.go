@@ -0,0 +1,20 @@
+package pjlink
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLampErrorCarriesAddress(t *testing.T) {
+	pr := scriptedDevice(t, map[string]string{"%1LAMP ?": "%1LAMP=1200 1 x 0"})
+
+	_, err := pr.Lamps()
+	var lampErr *LampResponseError
+	if !errors.As(err, &lampErr) || lampErr.Lamp != 2 {
+		t.Fatalf("Lamps() = %v, want a LampResponseError for lamp 2", err)
+	}
+	want := "pjlink: " + pr.hostPort() + ": LAMP: Invalid lamp response \"1200 1 x 0\": lamp 2: hours are not a number: \"x\""
+	if lampErr.Address != pr.hostPort() || err.Error() != want {
+		t.Errorf("Lamps() = %q, want %q", err, want)
+	}
+}
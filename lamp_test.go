@@ -0,0 +1,43 @@
+package pjlink_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+func TestParseLamps(t *testing.T) {
+	lamps, err := pjlink.ParseLamps([]string{"1200", "1", "30", "0"})
+	want := []pjlink.Lamp{{Hours: 1200, On: true}, {Hours: 30}}
+	if err != nil || !reflect.DeepEqual(lamps, want) {
+		t.Errorf("ParseLamps() = %+v, %v, want %+v", lamps, err, want)
+	}
+
+	tests := map[string]int{ // response and the lamp the error is reported for
+		"":                        0,
+		"1200":                    0,
+		"1200 1 30":               0,
+		"123456 1":                1,
+		"12 1 x 0":                2,
+		"12 2":                    1,
+		strings.Repeat("1 0 ", 9): 0,
+	}
+	for response, lamp := range tests {
+		_, err := pjlink.ParseLamps(strings.Fields(response))
+		var lampErr *pjlink.LampResponseError
+		if !errors.As(err, &lampErr) || lampErr.Lamp != lamp || !errors.Is(err, pjlink.ErrInvalidResponse) {
+			t.Errorf("ParseLamps(%q) = %v, want an error for lamp %d", response, err, lamp)
+			continue
+		}
+		var respErr *pjlink.ResponseError
+		if !errors.As(err, &respErr) || respErr.Command != "LAMP" || respErr.Response != strings.TrimSpace(response) {
+			t.Errorf("ParseLamps(%q) = %+v, want a ResponseError of LAMP", response, respErr)
+		}
+		if msg := err.Error(); !strings.HasPrefix(msg, "pjlink: LAMP: Invalid lamp response") {
+			t.Errorf("ParseLamps(%q) = %q", response, msg)
+		}
+	}
+}
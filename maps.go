@@ -11,6 +11,18 @@ var HumanToRawCommands = map[string]string{
 	"manufacturer": "INF1",
 	"model":        "INF2",
 	"version":      "INFO",
+	// Class 2
+	"serial-number":          "SNUM",
+	"software-version":       "SVER",
+	"input-name":             "INNM",
+	"input-resolution":       "IRES",
+	"recommended-resolution": "RRES",
+	"filter-usage":           "FILT",
+	"lamp-model":             "RLMP",
+	"filter-model":           "RFIL",
+	"speaker-volume":         "SVOL",
+	"microphone-volume":      "MVOL",
+	"freeze":                 "FREZ",
 }
 
 var RawToHumanCommands = map[string]string{
@@ -24,6 +36,25 @@ var RawToHumanCommands = map[string]string{
 	"INF1": "manufacturer",
 	"INF2": "model",
 	"INFO": "version",
+	// Class 2
+	"SNUM": "serial-number",
+	"SVER": "software-version",
+	"INNM": "input-name",
+	"IRES": "input-resolution",
+	"RRES": "recommended-resolution",
+	"FILT": "filter-usage",
+	"RLMP": "lamp-model",
+	"RFIL": "filter-model",
+	"SVOL": "speaker-volume",
+	"MVOL": "microphone-volume",
+	"FREZ": "freeze",
+}
+
+var ErrorResponses = map[string]string{
+	"ERR1": "undefined command",
+	"ERR2": "out of parameter",
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
 }
 
 var PowerRequests = map[string]string{
@@ -105,54 +136,74 @@ var InputRequests = map[string]string{
 	"network7": "57",
 	"network8": "58",
 	"network9": "59",
+	// Class 2
+	"internal1": "61",
+	"internal2": "62",
+	"internal3": "63",
+	"internal4": "64",
+	"internal5": "65",
+	"internal6": "66",
+	"internal7": "67",
+	"internal8": "68",
+	"internal9": "69",
 }
 
 var InputQueryResponses = map[string]string{
-	"11":   "rgb1",
-	"12":   "rgb2",
-	"13":   "rgb3",
-	"14":   "rgb4",
-	"15":   "rgb5",
-	"16":   "rgb6",
-	"17":   "rgb7",
-	"18":   "rgb8",
-	"19":   "rgb9",
-	"21":   "video1",
-	"22":   "video2",
-	"23":   "video3",
-	"24":   "video4",
-	"25":   "video5",
-	"26":   "video6",
-	"27":   "video7",
-	"28":   "video8",
-	"29":   "video9",
-	"31":   "digital1",
-	"32":   "digital2",
-	"33":   "digital3",
-	"34":   "digital4",
-	"35":   "digital5",
-	"36":   "digital6",
-	"37":   "digital7",
-	"38":   "digital8",
-	"39":   "digital9",
-	"41":   "storage1",
-	"42":   "storage2",
-	"43":   "storage3",
-	"44":   "storage4",
-	"45":   "storage5",
-	"46":   "storage6",
-	"47":   "storage7",
-	"48":   "storage8",
-	"49":   "storage9",
-	"51":   "network1",
-	"52":   "network2",
-	"53":   "network3",
-	"54":   "network4",
-	"55":   "network5",
-	"56":   "network6",
-	"57":   "network7",
-	"58":   "network8",
-	"59":   "network9",
+	"11": "rgb1",
+	"12": "rgb2",
+	"13": "rgb3",
+	"14": "rgb4",
+	"15": "rgb5",
+	"16": "rgb6",
+	"17": "rgb7",
+	"18": "rgb8",
+	"19": "rgb9",
+	"21": "video1",
+	"22": "video2",
+	"23": "video3",
+	"24": "video4",
+	"25": "video5",
+	"26": "video6",
+	"27": "video7",
+	"28": "video8",
+	"29": "video9",
+	"31": "digital1",
+	"32": "digital2",
+	"33": "digital3",
+	"34": "digital4",
+	"35": "digital5",
+	"36": "digital6",
+	"37": "digital7",
+	"38": "digital8",
+	"39": "digital9",
+	"41": "storage1",
+	"42": "storage2",
+	"43": "storage3",
+	"44": "storage4",
+	"45": "storage5",
+	"46": "storage6",
+	"47": "storage7",
+	"48": "storage8",
+	"49": "storage9",
+	"51": "network1",
+	"52": "network2",
+	"53": "network3",
+	"54": "network4",
+	"55": "network5",
+	"56": "network6",
+	"57": "network7",
+	"58": "network8",
+	"59": "network9",
+	// Class 2
+	"61":   "internal1",
+	"62":   "internal2",
+	"63":   "internal3",
+	"64":   "internal4",
+	"65":   "internal5",
+	"66":   "internal6",
+	"67":   "internal7",
+	"68":   "internal8",
+	"69":   "internal9",
 	"ERR3": "unavailable time",
 	"ERR4": "device failure",
 }
@@ -210,6 +261,16 @@ var RawToHumanInputs = map[string]string{
 	"57": "network7",
 	"58": "network8",
 	"59": "network9",
+	// Class 2
+	"61": "internal1",
+	"62": "internal2",
+	"63": "internal3",
+	"64": "internal4",
+	"65": "internal5",
+	"66": "internal6",
+	"67": "internal7",
+	"68": "internal8",
+	"69": "internal9",
 }
 
 var AVMuteRequests = map[string]string{
@@ -243,7 +304,7 @@ var ErrorStatusRequests = map[string]string{
 }
 
 var ErrorStatusQueryResponses = map[string]string{
-	//<a><b><c>...<f> - use interpretErrorStatusResponse() function
+	//<a><b><c>...<f> - use ParseErrorStatus() function
 	"ERR3": "unavailable time",
 	"ERR4": "device failure",
 }
@@ -253,7 +314,7 @@ var LampRequests = map[string]string{
 }
 
 var LampQueryResponses = map[string]string{
-	//<a> <b> - use interpretLampQueryResponse() function
+	//<a> <b> - use ParseLamps() function
 	"ERR3": "unavailable time",
 	"ERR4": "device failure",
 }
@@ -302,3 +363,101 @@ var VersionQueryResponses = map[string]string{
 	"ERR3": "unavailable time",
 	"ERR4": "device failure",
 }
+
+//--------------- Class 2 --------------------------------------------------------------------------------------------//
+
+var SerialNumberRequests = map[string]string{
+	"query": "?",
+}
+
+var SerialNumberQueryResponses = map[string]string{
+	//<serial number> - just pass through
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
+}
+
+var SoftwareVersionRequests = map[string]string{
+	"query": "?",
+}
+
+var SoftwareVersionQueryResponses = map[string]string{
+	//<software version> - just pass through
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
+}
+
+var InputNameRequests = map[string]string{
+	//?<input> - e.g. "?31"
+	"query": "?",
+}
+
+var InputNameQueryResponses = map[string]string{
+	//<input name> - just pass through
+	"ERR2": "nonexistent input source",
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
+}
+
+var ResolutionRequests = map[string]string{
+	"query": "?",
+}
+
+var ResolutionQueryResponses = map[string]string{
+	//<horizontal>x<vertical> - use parseResolution() function
+	"-":    "no signal",
+	"*":    "unknown signal",
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
+}
+
+var FilterUsageRequests = map[string]string{
+	"query": "?",
+}
+
+var FilterUsageQueryResponses = map[string]string{
+	//<hours> - 0 to 99999
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
+}
+
+var ReplacementModelRequests = map[string]string{
+	"query": "?",
+}
+
+var ReplacementModelQueryResponses = map[string]string{
+	//<model> <model> ... - space separated, empty if unknown
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
+}
+
+var VolumeRequests = map[string]string{
+	"volume-down": "0",
+	"volume-up":   "1",
+}
+
+var VolumeResponses = map[string]string{
+	"OK":   "successful execution",
+	"ERR2": "out of parameter",
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
+}
+
+var FreezeRequests = map[string]string{
+	"query":      "?",
+	"freeze-on":  "1",
+	"freeze-off": "0",
+}
+
+var FreezeQueryResponses = map[string]string{
+	"0":    "freeze off",
+	"1":    "freeze on",
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
+}
+
+var FreezeResponses = map[string]string{
+	"OK":   "successful execution, or state already current",
+	"ERR2": "out of parameter",
+	"ERR3": "unavailable time",
+	"ERR4": "device failure",
+}
@@ -0,0 +1,302 @@
+package pjlink
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// commands a Monitor polls if none are given
+var DefaultMonitorCommands = []string{"POWR", "INPT", "AVMT", "ERST", "LAMP"}
+
+// size of the channel of each subscriber, changes are dropped for subscribers that fall behind
+const changeBuffer = 64
+
+// Snapshot is the latest known state of a projector, only the values of polled commands are set
+type Snapshot struct {
+	Address     string      `json:"address"`
+	Power       PowerState  `json:"power"`
+	Input       Input       `json:"input"`
+	AVMute      AVMute      `json:"av-mute"`
+	ErrorStatus ErrorStatus `json:"error-status"`
+	Lamps       []Lamp      `json:"lamps"`
+	FilterHours int         `json:"filter-hours"`
+
+	Updated map[string]time.Time `json:"updated"`          // when each command was last read successfully
+	Errors  map[string]string    `json:"errors,omitempty"` // last error of each command, cleared on success
+}
+
+// Change is sent to subscribers when a polled value differs from the one before
+type Change struct {
+	Projector string    `json:"projector"`
+	Command   string    `json:"command"`
+	Time      time.Time `json:"time"`
+	Snapshot  Snapshot  `json:"snapshot"`
+}
+
+// reads a command into the snapshot and reports whether the value changed
+type poller func(ctx context.Context, pr *PJProjector, snapshot *Snapshot) (changed bool, err error)
+
+var monitorPollers = map[string]poller{
+	"POWR": func(ctx context.Context, pr *PJProjector, snapshot *Snapshot) (bool, error) {
+		return pollValue(pr.PowerContext(ctx))(&snapshot.Power)
+	},
+	"INPT": func(ctx context.Context, pr *PJProjector, snapshot *Snapshot) (bool, error) {
+		return pollValue(pr.GetInputContext(ctx))(&snapshot.Input)
+	},
+	"AVMT": func(ctx context.Context, pr *PJProjector, snapshot *Snapshot) (bool, error) {
+		return pollValue(pr.GetAVMuteContext(ctx))(&snapshot.AVMute)
+	},
+	"ERST": func(ctx context.Context, pr *PJProjector, snapshot *Snapshot) (bool, error) {
+		return pollValue(pr.ErrorStatusContext(ctx))(&snapshot.ErrorStatus)
+	},
+	"LAMP": func(ctx context.Context, pr *PJProjector, snapshot *Snapshot) (bool, error) {
+		return pollValue(pr.LampsContext(ctx))(&snapshot.Lamps)
+	},
+	"FILT": func(ctx context.Context, pr *PJProjector, snapshot *Snapshot) (bool, error) {
+		return pollValue(pr.GetFilterUsageContext(ctx))(&snapshot.FilterHours)
+	},
+}
+
+// stores value into the snapshot field unless err is set
+func pollValue[T any](value T, err error) func(field *T) (bool, error) {
+	return func(field *T) (bool, error) {
+		if err != nil {
+			return false, err
+		}
+		changed := !reflect.DeepEqual(*field, value)
+		*field = value
+		return changed, nil
+	}
+}
+
+// Monitor polls projectors at an interval and keeps the latest Snapshot of each of them.
+// A Monitor is safe for concurrent use.
+type Monitor struct {
+	interval time.Duration
+	commands []string
+
+	mu          sync.RWMutex
+	projectors  map[string]*monitored
+	subscribers map[chan Change]struct{}
+	ctx         context.Context // set while running
+	wg          sync.WaitGroup
+}
+
+type monitored struct {
+	projector *PJProjector
+	snapshot  Snapshot
+	polled    map[string]bool // commands read at least once, the first value counts as a change
+}
+
+// NewMonitor polls the given commands (POWR, INPT, AVMT, ERST, LAMP or FILT) every interval,
+// DefaultMonitorCommands if none are given
+func NewMonitor(interval time.Duration, commands ...string) *Monitor {
+	if len(commands) == 0 {
+		commands = DefaultMonitorCommands
+	}
+	return &Monitor{
+		interval:    interval,
+		commands:    commands,
+		projectors:  make(map[string]*monitored),
+		subscribers: make(map[chan Change]struct{}),
+	}
+}
+
+// Add starts monitoring the projector under name, right away if the Monitor is running
+func (m *Monitor) Add(name string, pr *PJProjector) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.projectors[name] = &monitored{
+		projector: pr,
+		snapshot:  Snapshot{Address: pr.hostPort(), Updated: make(map[string]time.Time), Errors: make(map[string]string)},
+		polled:    make(map[string]bool),
+	}
+	if m.ctx != nil {
+		m.start(m.ctx, name)
+	}
+}
+
+// Remove stops monitoring name
+func (m *Monitor) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.projectors, name)
+}
+
+// Run polls all projectors until ctx is done
+func (m *Monitor) Run(ctx context.Context) error {
+	for _, command := range m.commands {
+		if _, ok := monitorPollers[command]; !ok {
+			return &RequestError{Command: command, Reason: "Command can't be monitored"}
+		}
+	}
+
+	m.mu.Lock()
+	m.ctx = ctx
+	for name := range m.projectors {
+		m.start(ctx, name)
+	}
+	m.mu.Unlock()
+
+	<-ctx.Done()
+
+	m.mu.Lock()
+	m.ctx = nil
+	m.mu.Unlock()
+	m.wg.Wait()
+	return ctx.Err()
+}
+
+// starts the polling loop of name, m.mu has to be held
+func (m *Monitor) start(ctx context.Context, name string) {
+	current := m.projectors[name]
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			m.mu.RLock()
+			active := m.projectors[name] == current
+			m.mu.RUnlock()
+			if !active {
+				return // removed or replaced
+			}
+
+			m.poll(ctx, name, current)
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (m *Monitor) poll(ctx context.Context, name string, target *monitored) {
+	for _, command := range m.commands {
+		// poll into a copy so Snapshot never sees a half written value
+		m.mu.RLock()
+		snapshot := target.snapshot.clone()
+		m.mu.RUnlock()
+
+		changed, err := monitorPollers[command](ctx, target.projector, &snapshot)
+		if contextError(ctx) != nil {
+			return // stopping, not a problem of the projector
+		}
+		now := time.Now()
+		if err != nil {
+			snapshot.Errors[command] = err.Error()
+		} else {
+			delete(snapshot.Errors, command)
+			snapshot.Updated[command] = now
+		}
+
+		m.mu.Lock()
+		changed = err == nil && (changed || !target.polled[command])
+		if err == nil {
+			target.polled[command] = true
+		}
+		target.snapshot = snapshot
+		if changed {
+			m.publish(Change{Projector: name, Command: command, Time: now, Snapshot: snapshot.clone()})
+		}
+		m.mu.Unlock()
+	}
+}
+
+// sends to every subscriber that has room, m.mu has to be held
+func (m *Monitor) publish(change Change) {
+	for subscriber := range m.subscribers {
+		select {
+		case subscriber <- change:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel of changes and a function to unsubscribe, which closes the channel
+func (m *Monitor) Subscribe() (<-chan Change, func()) {
+	subscriber := make(chan Change, changeBuffer)
+
+	m.mu.Lock()
+	m.subscribers[subscriber] = struct{}{}
+	m.mu.Unlock()
+
+	var once sync.Once
+	return subscriber, func() {
+		once.Do(func() {
+			m.mu.Lock()
+			delete(m.subscribers, subscriber)
+			m.mu.Unlock()
+			close(subscriber)
+		})
+	}
+}
+
+// Projector returns the monitored projector called name
+func (m *Monitor) Projector(name string) (*PJProjector, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	target, ok := m.projectors[name]
+	if !ok {
+		return nil, false
+	}
+	return target.projector, true
+}
+
+// Snapshot returns the latest state of name
+func (m *Monitor) Snapshot(name string) (Snapshot, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	target, ok := m.projectors[name]
+	if !ok {
+		return Snapshot{}, false
+	}
+	return target.snapshot.clone(), true
+}
+
+// Snapshots returns the latest state of all projectors by name
+func (m *Monitor) Snapshots() map[string]Snapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	snapshots := make(map[string]Snapshot, len(m.projectors))
+	for name, target := range m.projectors {
+		snapshots[name] = target.snapshot.clone()
+	}
+	return snapshots
+}
+
+// Names lists the monitored projectors sorted by name
+func (m *Monitor) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.projectors))
+	for name := range m.projectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// deep copy, so callers can't change the state of the Monitor
+func (s Snapshot) clone() Snapshot {
+	clone := s
+	clone.Lamps = append([]Lamp(nil), s.Lamps...)
+	clone.Updated = make(map[string]time.Time, len(s.Updated))
+	for command, t := range s.Updated {
+		clone.Updated[command] = t
+	}
+	clone.Errors = make(map[string]string, len(s.Errors))
+	for command, err := range s.Errors {
+		clone.Errors[command] = err
+	}
+	return clone
+}
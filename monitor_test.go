@@ -0,0 +1,180 @@
+package pjlink_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+// runs monitor until the test ends
+func runMonitor(t *testing.T, monitor *pjlink.Monitor) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- monitor.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() = %v, want context.Canceled", err)
+		}
+	})
+}
+
+func nextChange(t *testing.T, changes <-chan pjlink.Change) pjlink.Change {
+	t.Helper()
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change")
+	}
+	return pjlink.Change{}
+}
+
+// fails if a change arrives while the projector is polled a few more times
+func expectNoChange(t *testing.T, srv *pjlinktest.Server, changes <-chan pjlink.Change) {
+	t.Helper()
+	polled := srv.RequestCount() + 6
+	eventually(t, "more polls", func() bool { return srv.RequestCount() >= polled })
+	select {
+	case change := <-changes:
+		t.Errorf("unexpected change of %s", change.Command)
+	default:
+	}
+}
+
+func eventually(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s didn't happen", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMonitorSendsOnlyChanges(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Inputs: []string{"11", "31"}})
+	defer srv.Close()
+
+	monitor := pjlink.NewMonitor(10*time.Millisecond, "POWR", "INPT")
+	monitor.Add("hall-a", srv.Projector())
+	changes, unsubscribe := monitor.Subscribe()
+	defer unsubscribe()
+	runMonitor(t, monitor)
+
+	// the first value of each command counts as a change
+	first := map[string]bool{}
+	for len(first) < 2 {
+		change := nextChange(t, changes)
+		if change.Projector != "hall-a" || first[change.Command] {
+			t.Fatalf("change %+v", change)
+		}
+		first[change.Command] = true
+	}
+	expectNoChange(t, srv, changes)
+
+	srv.SetPower(pjlink.PowerOn)
+	change := nextChange(t, changes)
+	if change.Command != "POWR" || change.Snapshot.Power != pjlink.PowerOn {
+		t.Errorf("change = %s %v, want POWR on", change.Command, change.Snapshot.Power)
+	}
+	expectNoChange(t, srv, changes)
+
+	snapshot, ok := monitor.Snapshot("hall-a")
+	if !ok || snapshot.Power != pjlink.PowerOn || snapshot.Input.Raw() != "11" || snapshot.Updated["POWR"].IsZero() {
+		t.Errorf("Snapshot() = %+v, %t", snapshot, ok)
+	}
+	if snapshot.Address != srv.Addr() {
+		t.Errorf("address = %s, want %s", snapshot.Address, srv.Addr())
+	}
+}
+
+func TestMonitorErrors(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{})
+	defer srv.Close()
+
+	monitor := pjlink.NewMonitor(10*time.Millisecond, "POWR")
+	monitor.Add("hall-a", srv.Projector())
+	changes, unsubscribe := monitor.Subscribe()
+	defer unsubscribe()
+	runMonitor(t, monitor)
+	nextChange(t, changes)
+
+	// failed polls keep the last value and report the error without a change
+	srv.SetFailure(true)
+	eventually(t, "POWR error", func() bool {
+		snapshot, _ := monitor.Snapshot("hall-a")
+		return snapshot.Errors["POWR"] != ""
+	})
+	if snapshot, _ := monitor.Snapshot("hall-a"); snapshot.Power != pjlink.PowerOff {
+		t.Errorf("power = %v after a failed poll, want the last value", snapshot.Power)
+	}
+	expectNoChange(t, srv, changes)
+
+	srv.SetFailure(false)
+	eventually(t, "error cleared", func() bool {
+		snapshot, _ := monitor.Snapshot("hall-a")
+		return len(snapshot.Errors) == 0
+	})
+}
+
+func TestMonitorSubscribeAndRemove(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{})
+	defer srv.Close()
+
+	monitor := pjlink.NewMonitor(10*time.Millisecond, "POWR")
+	monitor.Add("hall-a", srv.Projector())
+	changes, unsubscribe := monitor.Subscribe()
+	other, unsubscribeOther := monitor.Subscribe()
+	defer unsubscribeOther()
+	runMonitor(t, monitor)
+
+	nextChange(t, changes)
+	nextChange(t, other)
+
+	// unsubscribing closes the channel and may be repeated
+	unsubscribe()
+	unsubscribe()
+	if _, open := <-changes; open {
+		t.Error("channel open after unsubscribe")
+	}
+
+	// projectors added while running are polled right away
+	added := pjlinktest.NewServer(pjlinktest.Profile{})
+	defer added.Close()
+	monitor.Add("hall-b", added.Projector())
+	if change := nextChange(t, other); change.Projector != "hall-b" {
+		t.Errorf("change of %s, want hall-b", change.Projector)
+	}
+	if names := monitor.Names(); len(names) != 2 || names[0] != "hall-a" || names[1] != "hall-b" {
+		t.Errorf("Names() = %q", names)
+	}
+
+	monitor.Remove("hall-a")
+	if _, ok := monitor.Snapshot("hall-a"); ok {
+		t.Error("snapshot of a removed projector")
+	}
+	if _, ok := monitor.Projector("hall-a"); ok {
+		t.Error("removed projector still monitored")
+	}
+	time.Sleep(30 * time.Millisecond) // a poll that was running when it was removed
+	polled := srv.RequestCount()
+	time.Sleep(50 * time.Millisecond)
+	if srv.RequestCount() != polled {
+		t.Error("removed projector still polled")
+	}
+}
+
+func TestMonitorRejectsUnknownCommands(t *testing.T) {
+	err := pjlink.NewMonitor(time.Second, "POWR", "NAME").Run(context.Background())
+	var reqErr *pjlink.RequestError
+	if !errors.As(err, &reqErr) || reqErr.Command != "NAME" {
+		t.Errorf("Run() = %v, want a RequestError for NAME", err)
+	}
+}
@@ -0,0 +1,243 @@
+// Package mqttbridge connects the projectors of a pjlink.Monitor to an MQTT broker.
+//
+// The state of each projector is published as retained messages, one topic per command named
+// like in pjlink.HumanToRawCommands:
+//
+//	pjlink/<name>/power         on
+//	pjlink/<name>/input         digital1
+//	pjlink/<name>/av-mute       {"video":true,"audio":false}
+//	pjlink/<name>/error-status  {"fan":"ok",...}
+//	pjlink/<name>/lamp          [{"hours":1234,"on":true}]
+//	pjlink/<name>/filter-usage  560
+//
+// Messages to pjlink/<name>/set/<command> change the projector, the payload is either the human
+// readable parameter from the maps of the pjlink package or the raw parameter:
+//
+//	pjlink/hall-a/set/power    on
+//	pjlink/hall-a/set/input    digital1
+//	pjlink/hall-a/set/av-mute  av-mute-on
+//	pjlink/hall-a/set/freeze   1
+//
+// Failed commands are reported on pjlink/<name>/error.
+package mqttbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	defaultPrefix  = "pjlink"
+	defaultTimeout = 10 * time.Second
+)
+
+// human readable parameters of the commands that can be set, POWR, INPT and AVMT are handled on their own
+var setRequests = map[string]map[string]string{
+	"SVOL": pjlink.VolumeRequests,
+	"MVOL": pjlink.VolumeRequests,
+	"FREZ": pjlink.FreezeRequests,
+}
+
+// Bridge publishes the changes seen by a Monitor and forwards set commands to its projectors.
+// Set its fields before calling Run.
+type Bridge struct {
+	Prefix  string        // first topic level, "pjlink" if not set
+	QoS     byte          // of published messages and the subscription
+	Timeout time.Duration // limit of a set command, 10s if not set
+	Logger  *log.Logger   // logs failed commands and publishes if set
+
+	client  mqtt.Client
+	monitor *pjlink.Monitor
+}
+
+// New returns a Bridge for the projectors of monitor, client has to be connected before Run.
+// The Monitor has to be run separately.
+func New(client mqtt.Client, monitor *pjlink.Monitor) *Bridge {
+	return &Bridge{client: client, monitor: monitor}
+}
+
+// Run publishes state and handles set commands until ctx is done
+func (b *Bridge) Run(ctx context.Context) error {
+	changes, unsubscribe := b.monitor.Subscribe()
+	defer unsubscribe()
+
+	setTopic := b.prefix() + "/+/set/+"
+	if err := wait(ctx, b.client.Subscribe(setTopic, b.QoS, b.handleSet(ctx))); err != nil {
+		return err
+	}
+	defer b.client.Unsubscribe(setTopic)
+
+	// values read before the subscription started
+	for name, snapshot := range b.monitor.Snapshots() {
+		for command := range snapshot.Updated {
+			b.publishState(ctx, name, command, snapshot)
+		}
+	}
+
+	for {
+		select {
+		case change := <-changes:
+			b.publishState(ctx, change.Projector, change.Command, change.Snapshot)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (b *Bridge) publishState(ctx context.Context, name string, command string, snapshot pjlink.Snapshot) {
+	payload, err := statePayload(command, snapshot)
+	if err != nil {
+		b.logf("%s: %s: %v", name, command, err)
+		return
+	}
+	topic := b.prefix() + "/" + name + "/" + pjlink.RawToHumanCommands[command]
+	if err := wait(ctx, b.client.Publish(topic, b.QoS, true, payload)); err != nil {
+		b.logf("failed to publish %s: %v", topic, err)
+	}
+}
+
+// the payload of the retained state topic of command
+func statePayload(command string, snapshot pjlink.Snapshot) ([]byte, error) {
+	switch command {
+	case "POWR":
+		return []byte(snapshot.Power.String()), nil
+	case "INPT":
+		return []byte(snapshot.Input.String()), nil
+	case "AVMT":
+		return json.Marshal(snapshot.AVMute)
+	case "ERST":
+		return json.Marshal(snapshot.ErrorStatus)
+	case "LAMP":
+		return json.Marshal(snapshot.Lamps)
+	case "FILT":
+		return []byte(strconv.Itoa(snapshot.FilterHours)), nil
+	}
+	return nil, &pjlink.RequestError{Command: command, Reason: "Command can't be published"}
+}
+
+// handles messages on <prefix>/<name>/set/<command>, the commands run outside the client's
+// goroutine so a slow projector doesn't hold up other messages
+func (b *Bridge) handleSet(ctx context.Context) mqtt.MessageHandler {
+	return func(_ mqtt.Client, msg mqtt.Message) {
+		levels := strings.Split(strings.TrimPrefix(msg.Topic(), b.prefix()+"/"), "/")
+		if len(levels) != 3 || levels[1] != "set" {
+			return
+		}
+		name, property, payload := levels[0], levels[2], strings.TrimSpace(string(msg.Payload()))
+
+		go func() {
+			if err := b.set(ctx, name, property, payload); err != nil {
+				b.logf("%s: set %s to %q: %v", name, property, payload, err)
+				b.client.Publish(b.prefix()+"/"+name+"/error", b.QoS, false, err.Error())
+			}
+		}()
+	}
+}
+
+func (b *Bridge) set(ctx context.Context, name string, property string, payload string) error {
+	pr, ok := b.monitor.Projector(name)
+	if !ok {
+		return errors.New("unknown projector " + name)
+	}
+	command, ok := pjlink.HumanToRawCommands[property]
+	if !ok {
+		return &pjlink.RequestError{Command: property, Reason: "Unknown command"}
+	}
+
+	timeout := b.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch command {
+	case "POWR":
+		switch payload {
+		case "on", "power-on", pjlink.PowerRequests["power-on"]:
+			return pr.TurnOnContext(ctx)
+		case "off", "power-off", pjlink.PowerRequests["power-off"]:
+			return pr.TurnOffContext(ctx)
+		}
+		return &pjlink.RequestError{Command: command, Reason: "Invalid power state: " + payload}
+	case "INPT":
+		in, err := pjlink.ParseHumanInput(payload)
+		if err != nil {
+			if in, err = pjlink.ParseInput(payload); err != nil {
+				return err
+			}
+		}
+		return pr.SetInputContext(ctx, in)
+	case "AVMT":
+		parameter := payload
+		if raw, ok := pjlink.AVMuteRequests[payload]; ok {
+			parameter = raw
+		}
+		return setAVMute(ctx, pr, parameter)
+	}
+
+	parameter := payload
+	if raw, ok := setRequests[command][payload]; ok {
+		parameter = raw
+	}
+	if parameter == "?" {
+		return &pjlink.RequestError{Command: command, Reason: "Queries can't be sent as set commands"}
+	}
+	if pjlink.CommandMapClass1[command] {
+		return pr.SetPropertyContext(ctx, command, parameter)
+	}
+
+	resp, err := pr.SendRequestContext(ctx, pjlink.PJRequest{Class: 2, Command: command, Parameter: parameter})
+	if err != nil {
+		return err
+	}
+	return resp.Err()
+}
+
+// sets AV mute through the typed calls, so it is sent with the class of the projector.
+// parameter is raw, e.g. "11" for video mute on.
+func setAVMute(ctx context.Context, pr *pjlink.PJProjector, parameter string) error {
+	if len(parameter) == 2 && (parameter[1] == '0' || parameter[1] == '1') {
+		on := parameter[1] == '1'
+		switch parameter[0] {
+		case '1':
+			return pr.SetVideoMuteContext(ctx, on)
+		case '2':
+			return pr.SetAudioMuteContext(ctx, on)
+		case '3':
+			return pr.SetAVMuteContext(ctx, on)
+		}
+	}
+	return &pjlink.RequestError{Command: "AVMT", Reason: "Invalid AV mute: " + parameter}
+}
+
+func (b *Bridge) prefix() string {
+	if b.Prefix == "" {
+		return defaultPrefix
+	}
+	return b.Prefix
+}
+
+func (b *Bridge) logf(format string, args ...any) {
+	if b.Logger != nil {
+		b.Logger.Printf(format, args...)
+	}
+}
+
+// waits for token unless ctx is done first
+func wait(ctx context.Context, token mqtt.Token) error {
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
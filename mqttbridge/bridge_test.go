@@ -0,0 +1,210 @@
+package mqttbridge_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/mqttbridge"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+)
+
+// starts an in-process MQTT broker and returns its URL
+func startBroker(t *testing.T) string {
+	t.Helper()
+	broker := mochi.New(&mochi.Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err := broker.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatal(err)
+	}
+	tcp := listeners.NewTCP(listeners.Config{ID: "test", Address: "127.0.0.1:0"})
+	if err := broker.AddListener(tcp); err != nil {
+		t.Fatal(err)
+	}
+	if err := broker.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { broker.Close() })
+	return "tcp://" + tcp.Address()
+}
+
+func connect(t *testing.T, broker string, id string) mqtt.Client {
+	t.Helper()
+	client := mqtt.NewClient(mqtt.NewClientOptions().AddBroker(broker).SetClientID(id))
+	if token := client.Connect(); token.Wait() && token.Error() != nil {
+		t.Fatal(token.Error())
+	}
+	t.Cleanup(func() { client.Disconnect(0) })
+	return client
+}
+
+type message struct {
+	topic    string
+	payload  string
+	retained bool
+}
+
+// subscribes to filter and delivers the messages on the returned channel
+func subscribe(t *testing.T, client mqtt.Client, filter string) <-chan message {
+	t.Helper()
+	messages := make(chan message, 64)
+	token := client.Subscribe(filter, 1, func(_ mqtt.Client, msg mqtt.Message) {
+		messages <- message{topic: msg.Topic(), payload: string(msg.Payload()), retained: msg.Retained()}
+	})
+	if token.Wait() && token.Error() != nil {
+		t.Fatal(token.Error())
+	}
+	return messages
+}
+
+// waits for a message on topic with payload, other messages are skipped
+func expect(t *testing.T, messages <-chan message, topic string, payload string) message {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg := <-messages:
+			if msg.topic == topic && (payload == "" || msg.payload == payload) {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("no message %q on %s", payload, topic)
+		}
+	}
+}
+
+// waits for the payloads of want by topic and returns the messages, other messages are skipped
+func expectAll(t *testing.T, messages <-chan message, want map[string]string) []message {
+	t.Helper()
+	var got []message
+	seen := map[string]bool{}
+	timeout := time.After(5 * time.Second)
+	for len(seen) < len(want) {
+		select {
+		case msg := <-messages:
+			if payload, ok := want[msg.topic]; ok && payload == msg.payload && !seen[msg.topic] {
+				seen[msg.topic] = true
+				got = append(got, msg)
+			}
+		case <-timeout:
+			t.Fatalf("got %d of the messages %v", len(seen), want)
+		}
+	}
+	return got
+}
+
+func eventually(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s didn't happen", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// runs a bridge for a Class 2 projector called hall-a
+func startBridge(t *testing.T) (*pjlinktest.Server, string) {
+	t.Helper()
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Class: 2, Password: "secret", Inputs: []string{"31", "32"}})
+	t.Cleanup(func() { srv.Close() })
+
+	monitor := pjlink.NewMonitor(20*time.Millisecond, "POWR", "INPT", "AVMT")
+	monitor.Add("hall-a", srv.Projector())
+
+	broker := startBroker(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{}, 2)
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		<-done
+	})
+
+	bridge := mqttbridge.New(connect(t, broker, "bridge"), monitor)
+	go func() { monitor.Run(ctx); done <- struct{}{} }()
+	go func() { bridge.Run(ctx); done <- struct{}{} }()
+	return srv, broker
+}
+
+func TestRetainedState(t *testing.T) {
+	srv, broker := startBridge(t)
+
+	// the bridge publishes the first poll, a client subscribing later still gets it
+	want := map[string]string{
+		"pjlink/hall-a/power":   "off",
+		"pjlink/hall-a/input":   "digital1",
+		"pjlink/hall-a/av-mute": `{"video":false,"audio":false}`,
+	}
+	watcher := subscribe(t, connect(t, broker, "watcher"), "pjlink/hall-a/#")
+	expectAll(t, watcher, want)
+
+	late := subscribe(t, connect(t, broker, "late"), "pjlink/hall-a/#")
+	for _, msg := range expectAll(t, late, want) {
+		if !msg.retained {
+			t.Errorf("%s isn't retained", msg.topic)
+		}
+	}
+
+	// changes on the device are published as they are polled
+	srv.SetPower(pjlink.PowerOn)
+	expect(t, watcher, "pjlink/hall-a/power", "on")
+}
+
+func TestSet(t *testing.T) {
+	srv, broker := startBridge(t)
+	client := connect(t, broker, "controller")
+	watcher := subscribe(t, client, "pjlink/hall-a/#")
+
+	publish := func(property string, payload string) {
+		t.Helper()
+		if token := client.Publish("pjlink/hall-a/set/"+property, 1, false, payload); token.Wait() && token.Error() != nil {
+			t.Fatal(token.Error())
+		}
+	}
+
+	publish("power", "on")
+	eventually(t, "power on", func() bool { return srv.Power() == pjlink.PowerOn })
+	expect(t, watcher, "pjlink/hall-a/power", "on")
+
+	publish("input", "digital2")
+	eventually(t, "input 32", func() bool { return srv.Input() == "32" })
+	expect(t, watcher, "pjlink/hall-a/input", "digital2")
+
+	publish("av-mute", "video-mute-on")
+	eventually(t, "video mute", func() bool { return srv.AVMute() == pjlink.AVMute{Video: true} })
+	expect(t, watcher, "pjlink/hall-a/av-mute", `{"video":true,"audio":false}`)
+
+	publish("av-mute", "30")
+	eventually(t, "mute off", func() bool { return srv.AVMute() == pjlink.AVMute{} })
+
+	publish("freeze", "1")
+	eventually(t, "freeze", srv.Freeze)
+
+	publish("speaker-volume", "volume-up")
+	eventually(t, "volume up", func() bool { return srv.Volume("SVOL") == 1 })
+
+	// AV mute goes out as Class 2 to a Class 2 projector
+	found := false
+	for _, request := range srv.Requests() {
+		found = found || request == "%2AVMT 11"
+	}
+	if !found {
+		t.Errorf("no %%2AVMT 11 in %q", srv.Requests())
+	}
+
+	publish("power", "sideways")
+	if msg := expect(t, watcher, "pjlink/hall-a/error", ""); !strings.Contains(msg.payload, "sideways") {
+		t.Errorf("error = %q, want the invalid power state", msg.payload)
+	}
+	publish("input", "digital9")
+	expect(t, watcher, "pjlink/hall-a/error", "")
+}
@@ -0,0 +1,231 @@
+package pjlink
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// size of the Events channel of a Listener
+const eventBuffer = 64
+
+// Event is a status notification pushed by a Class 2 projector over UDP,
+// one of *LinkUpEvent, *ErrorStatusEvent, *PowerEvent or *InputEvent
+type Event interface {
+	Source() string
+	ReceivedAt() time.Time
+}
+
+// Notification holds what every Event has in common
+type Notification struct {
+	Address  string      `json:"address"` // IP address of the projector that sent the notification
+	Time     time.Time   `json:"time"`
+	Response *PJResponse `json:"response"`
+}
+
+func (n Notification) Source() string {
+	return n.Address
+}
+
+func (n Notification) ReceivedAt() time.Time {
+	return n.Time
+}
+
+// LinkUpEvent is sent when the projector connected to the network (LKUP)
+type LinkUpEvent struct {
+	Notification
+	MAC string `json:"mac"`
+}
+
+// ErrorStatusEvent is sent when the error status changed (ERST)
+type ErrorStatusEvent struct {
+	Notification
+	Status ErrorStatus `json:"status"`
+}
+
+// PowerEvent is sent when the projector finished turning on or off (POWR)
+type PowerEvent struct {
+	Notification
+	State PowerState `json:"state"`
+}
+
+// InputEvent is sent when the input changed (INPT)
+type InputEvent struct {
+	Notification
+	Input Input `json:"input"`
+}
+
+// Listener receives the status notifications of Class 2 projectors
+type Listener struct {
+	conn   net.PacketConn
+	events chan Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Listen binds addr for status notifications, an empty addr listens on the PJLink port of all interfaces
+func Listen(addr string) (*Listener, error) {
+	if addr == "" {
+		addr = ":" + pjLinkPort
+	}
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, &NetworkError{Address: addr, Op: "listen", Err: err}
+	}
+
+	l := &Listener{
+		conn:   conn,
+		events: make(chan Event, eventBuffer),
+		done:   make(chan struct{}),
+	}
+	go l.receive()
+	return l, nil
+}
+
+// Events delivers the notifications, it is closed when the Listener is closed.
+// Datagrams which are not valid notifications are dropped.
+func (l *Listener) Events() <-chan Event {
+	return l.events
+}
+
+func (l *Listener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
+
+func (l *Listener) Close() error {
+	err := error(nil)
+	l.once.Do(func() {
+		close(l.done)
+		err = l.conn.Close()
+	})
+	return err
+}
+
+func (l *Listener) receive() {
+	defer close(l.events)
+
+	buf := make([]byte, 1024)
+	for {
+		n, addr, err := l.conn.ReadFrom(buf)
+		if err != nil {
+			select {
+			case <-l.done:
+				return
+			default:
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				continue
+			}
+			return
+		}
+
+		event, err := parseNotification(string(buf[:n]), addr, time.Now())
+		if err != nil {
+			continue
+		}
+
+		select {
+		case l.events <- event:
+		case <-l.done:
+			return
+		}
+	}
+}
+
+// decodes a single notification datagram such as "%2POWR=1\r"
+func parseNotification(raw string, addr net.Addr, received time.Time) (Event, error) {
+	resp := NewPJResponse()
+	if err := resp.Parse(strings.TrimRight(raw, "\r\n")); err != nil {
+		return nil, err
+	}
+
+	notification := Notification{
+		Address:  hostOf(addr),
+		Time:     received,
+		Response: resp,
+	}
+	value := resp.Response[0]
+
+	switch resp.Command {
+	case "LKUP":
+		if _, err := net.ParseMAC(value); err != nil {
+			return nil, &ResponseError{Address: notification.Address, Command: resp.Command, Response: value, Reason: "Invalid MAC address: " + value}
+		}
+		return &LinkUpEvent{Notification: notification, MAC: strings.ToLower(value)}, nil
+	case "ERST":
+		status, err := ParseErrorStatus(value)
+		if err != nil {
+			return nil, err
+		}
+		return &ErrorStatusEvent{Notification: notification, Status: status}, nil
+	case "POWR":
+		state, err := parsePowerState(value)
+		if err != nil {
+			return nil, err
+		}
+		return &PowerEvent{Notification: notification, State: state}, nil
+	case "INPT":
+		input, ok := parseInput(value)
+		if !ok {
+			return nil, &ResponseError{Address: notification.Address, Command: resp.Command, Response: value, Reason: "Invalid input: " + value}
+		}
+		return &InputEvent{Notification: notification, Input: input}, nil
+	}
+	return nil, &ResponseError{Address: notification.Address, Command: resp.Command, Response: raw, Reason: "Not a status notification"}
+}
+
+// IP address of a UDP or TCP peer
+func hostOf(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+// Class 2 projectors send their status notifications to the controller that connected last.
+// SetNotificationTarget connects to the projector from this host, so it becomes the target, and
+// returns the local address the projector saw.
+func (pr *PJProjector) SetNotificationTarget() (string, error) {
+	return pr.SetNotificationTargetContext(context.Background())
+}
+
+func (pr *PJProjector) SetNotificationTargetContext(ctx context.Context) (string, error) {
+	release, err := pr.acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	// a fresh connection, an open session may have been established from another address
+	if pr.session != nil {
+		pr.session.Close()
+		pr.session = nil
+	}
+	conn, err := pr.dial(ctx)
+	if err != nil {
+		return "", pr.annotate(err, "CLSS")
+	}
+	defer conn.Close()
+
+	resp, err := conn.exchange(ctx, PJRequest{Class: 1, Command: "CLSS", Parameter: "?"}, pr.password)
+	if err != nil {
+		return "", pr.annotate(err, "CLSS")
+	}
+	if err := resp.Err(); err != nil {
+		return "", pr.annotate(err, "CLSS")
+	}
+	return hostOf(conn.LocalAddr()), nil
+}
+
+// NotificationTarget always fails with ErrUnsupported, PJLink has no command to query where a
+// projector sends its notifications. Use SetNotificationTarget to make this host the target.
+func (pr *PJProjector) NotificationTarget() (string, error) {
+	return "", fmt.Errorf("%s: %w", describe(pr.hostPort(), "", "the notification target can't be queried"), ErrUnsupported)
+}
@@ -0,0 +1,103 @@
+package pjlink_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+func listen(t *testing.T) *pjlink.Listener {
+	t.Helper()
+	l, err := pjlink.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+func nextEvent(t *testing.T, l *pjlink.Listener) pjlink.Event {
+	t.Helper()
+	select {
+	case event := <-l.Events():
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+	return nil
+}
+
+func TestListenerEvents(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Class: 2, MAC: "00:1A:2B:3C:4D:5E", Inputs: []string{"11", "3A"}})
+	defer srv.Close()
+	l := listen(t)
+	target := l.Addr().String()
+
+	notify := func(command string) pjlink.Event {
+		t.Helper()
+		if err := srv.Notify(target, command); err != nil {
+			t.Fatal(err)
+		}
+		event := nextEvent(t, l)
+		if event.Source() != "127.0.0.1" || event.ReceivedAt().IsZero() {
+			t.Errorf("%s came from %q at %v", command, event.Source(), event.ReceivedAt())
+		}
+		return event
+	}
+
+	if event, ok := notify("LKUP").(*pjlink.LinkUpEvent); !ok || event.MAC != "00:1a:2b:3c:4d:5e" {
+		t.Errorf("LKUP = %+v, want the MAC in lower case", event)
+	}
+
+	srv.SetPower(pjlink.PowerOn)
+	if event, ok := notify("POWR").(*pjlink.PowerEvent); !ok || event.State != pjlink.PowerOn {
+		t.Errorf("POWR = %+v, want on", event)
+	}
+
+	srv.SetInput("3A")
+	want := pjlink.Input{Type: pjlink.InputDigital, Index: 10}
+	if event, ok := notify("INPT").(*pjlink.InputEvent); !ok || event.Input != want {
+		t.Errorf("INPT = %+v, want %v", event, want)
+	}
+
+	status := pjlink.ErrorStatus{Lamp: pjlink.ErrorLevelError, Filter: pjlink.ErrorLevelWarning}
+	srv.SetErrorStatus(status)
+	event, ok := notify("ERST").(*pjlink.ErrorStatusEvent)
+	if !ok || event.Status != status {
+		t.Errorf("ERST = %+v, want %+v", event, status)
+	}
+	if event.Response == nil || event.Response.Command != "ERST" {
+		t.Errorf("ERST response = %+v", event.Response)
+	}
+}
+
+func TestListenerDropsInvalidDatagrams(t *testing.T) {
+	l := listen(t)
+	conn, err := net.Dial("udp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, datagram := range []string{"garbage", "%2POWR=7\r", "%2LKUP=not-a-mac\r", "%2INPT=99\r", "%2ERST=0\r", "%1NAME=hall\r", "%2POWR=1\r"} {
+		if _, err := conn.Write([]byte(datagram)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if event, ok := nextEvent(t, l).(*pjlink.PowerEvent); !ok || event.State != pjlink.PowerOn {
+		t.Errorf("first event = %+v, want the valid POWR", event)
+	}
+
+	l.Close()
+	select {
+	case _, open := <-l.Events():
+		if open {
+			t.Error("event after Close()")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Events() not closed by Close()")
+	}
+}
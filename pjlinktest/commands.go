@@ -0,0 +1,286 @@
+package pjlinktest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+// answers of the PJLink spec other than a value
+const (
+	respOK               = "OK"
+	respUndefined        = "ERR1"
+	respOutOfParameter   = "ERR2"
+	respUnavailableTime  = "ERR3"
+	respProjectorFailure = "ERR4"
+)
+
+// commands kept for Requests, older ones are dropped so long running servers don't grow
+const maxRequests = 1000
+
+// answers a single command, line is "%<class><command> <parameter>" without the digest
+func (s *Server) respond(line string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.requests) == maxRequests {
+		s.requests = append(s.requests[:0], s.requests[1:]...)
+	}
+	s.requests = append(s.requests, line)
+	s.received++
+
+	if len(line) < 8 || line[0] != '%' || line[6] != ' ' {
+		header := "%1" + "????"
+		if len(line) >= 6 && line[0] == '%' {
+			header = line[:6]
+		}
+		return header + "=" + respUndefined
+	}
+	header, class, command, parameter := line[:6], line[1], line[2:6], line[7:]
+
+	return header + "=" + s.execute(class, command, parameter)
+}
+
+func (s *Server) execute(class byte, command string, parameter string) string {
+	switch {
+	case class == '1' && pjlink.CommandMapClass1[command]:
+	case class == '2' && s.profile.Class >= 2 && pjlink.CommandMapClass2[command]:
+	default:
+		return respUndefined
+	}
+	if s.failure {
+		return respProjectorFailure
+	}
+	s.settle()
+
+	switch command {
+	case "POWR":
+		return s.executePower(parameter)
+	case "INPT":
+		return s.executeInput(class, parameter)
+	case "AVMT":
+		return s.executeAVMute(parameter)
+	case "FREZ":
+		return s.executeFreeze(parameter)
+	case "SVOL", "MVOL":
+		switch parameter {
+		case "1":
+			s.volume[command]++
+		case "0":
+			s.volume[command]--
+		default:
+			return respOutOfParameter
+		}
+		return respOK
+	case "INNM":
+		input := strings.TrimPrefix(parameter, "?")
+		if len(parameter) != 3 || parameter[0] != '?' || !s.hasInput(input) {
+			return respOutOfParameter
+		}
+		if name, ok := s.profile.InputNames[input]; ok {
+			return name
+		}
+		in, _ := pjlink.ParseInput(input)
+		return in.String()
+	}
+
+	if parameter != "?" {
+		return respOutOfParameter
+	}
+
+	switch command {
+	case "INST":
+		inputs := make([]string, 0, len(s.profile.Inputs))
+		for _, input := range s.profile.Inputs {
+			if class == '2' || isClass1Input(input) {
+				inputs = append(inputs, input)
+			}
+		}
+		return strings.Join(inputs, " ")
+	case "ERST":
+		return encodeErrorStatus(s.errorStatus)
+	case "LAMP":
+		lamps := make([]string, 0, 2*len(s.lamps))
+		for _, lamp := range s.lamps {
+			state := "0"
+			if lamp.On {
+				state = "1"
+			}
+			lamps = append(lamps, strconv.Itoa(lamp.Hours), state)
+		}
+		return strings.Join(lamps, " ")
+	case "NAME":
+		return s.profile.Name
+	case "INF1":
+		return s.profile.Manufacturer
+	case "INF2":
+		return s.profile.Model
+	case "INFO":
+		return s.profile.Info
+	case "CLSS":
+		return strconv.Itoa(s.profile.Class)
+	case "SNUM":
+		return s.profile.SerialNumber
+	case "SVER":
+		return s.profile.SoftwareVersion
+	case "IRES":
+		if s.power != pjlink.PowerOn {
+			return "-"
+		}
+		return s.profile.Resolution
+	case "RRES":
+		return s.profile.Resolution
+	case "FILT":
+		return strconv.Itoa(s.filterHours)
+	case "RLMP":
+		return strings.Join(s.profile.LampModels, " ")
+	case "RFIL":
+		return strings.Join(s.profile.FilterModels, " ")
+	}
+	return respUndefined
+}
+
+func (s *Server) executePower(parameter string) string {
+	switch parameter {
+	case "?":
+		return strconv.Itoa(int(s.power))
+	case "1":
+		switch s.power {
+		case pjlink.PowerOn:
+		case pjlink.PowerOff:
+			s.power = pjlink.PowerWarmUp
+			s.transition = time.Now().Add(s.profile.WarmUp)
+			s.settle()
+		default:
+			return respUnavailableTime
+		}
+		return respOK
+	case "0":
+		switch s.power {
+		case pjlink.PowerOff:
+		case pjlink.PowerOn:
+			s.power = pjlink.PowerCooling
+			s.transition = time.Now().Add(s.profile.CoolDown)
+			s.setLamps(false)
+			s.settle()
+		default:
+			return respUnavailableTime
+		}
+		return respOK
+	}
+	return respOutOfParameter
+}
+
+func (s *Server) executeInput(class byte, parameter string) string {
+	if parameter == "?" {
+		return s.input
+	}
+	if !s.hasInput(parameter) || (class == '1' && !isClass1Input(parameter)) {
+		return respOutOfParameter
+	}
+	if s.power != pjlink.PowerOn {
+		return respUnavailableTime
+	}
+	s.input = parameter
+	return respOK
+}
+
+func (s *Server) executeAVMute(parameter string) string {
+	if parameter == "?" {
+		return encodeAVMute(s.mute)
+	}
+
+	mute := s.mute
+	switch parameter {
+	case "11", "10":
+		mute.Video = parameter[1] == '1'
+	case "21", "20":
+		mute.Audio = parameter[1] == '1'
+	case "31", "30":
+		mute.Video = parameter[1] == '1'
+		mute.Audio = parameter[1] == '1'
+	default:
+		return respOutOfParameter
+	}
+	if s.power != pjlink.PowerOn {
+		return respUnavailableTime
+	}
+	s.mute = mute
+	return respOK
+}
+
+func (s *Server) executeFreeze(parameter string) string {
+	switch parameter {
+	case "?":
+		if s.freeze {
+			return "1"
+		}
+		return "0"
+	case "1", "0":
+		if s.power != pjlink.PowerOn {
+			return respUnavailableTime
+		}
+		s.freeze = parameter == "1"
+		return respOK
+	}
+	return respOutOfParameter
+}
+
+// finishes warm-up or cooling once its time is up
+func (s *Server) settle() {
+	if time.Now().Before(s.transition) {
+		return
+	}
+	switch s.power {
+	case pjlink.PowerWarmUp:
+		s.power = pjlink.PowerOn
+		s.setLamps(true)
+	case pjlink.PowerCooling:
+		s.power = pjlink.PowerOff
+		s.mute = pjlink.AVMute{}
+		s.freeze = false
+	}
+}
+
+func (s *Server) setLamps(on bool) {
+	for i := range s.lamps {
+		s.lamps[i].On = on
+	}
+}
+
+func (s *Server) hasInput(raw string) bool {
+	for _, input := range s.profile.Inputs {
+		if input == raw {
+			return true
+		}
+	}
+	return false
+}
+
+// Class 1 only knows the source types 1-5 with the numbers 1-9
+func isClass1Input(raw string) bool {
+	return len(raw) == 2 && raw[0] >= '1' && raw[0] <= '5' && raw[1] >= '1' && raw[1] <= '9'
+}
+
+func encodeAVMute(mute pjlink.AVMute) string {
+	switch {
+	case mute.Video && mute.Audio:
+		return "31"
+	case mute.Video:
+		return "11"
+	case mute.Audio:
+		return "21"
+	}
+	return "30"
+}
+
+func encodeErrorStatus(status pjlink.ErrorStatus) string {
+	var raw strings.Builder
+	for _, component := range pjlink.ErrorStatusComponents {
+		level, _ := status.Level(component)
+		raw.WriteString(strconv.Itoa(int(level)))
+	}
+	return raw.String()
+}
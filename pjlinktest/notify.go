@@ -0,0 +1,72 @@
+package pjlinktest
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Notify sends the Class 2 status notification for command (LKUP, ERST, POWR or INPT)
+// with the current state to the UDP address target, e.g. "127.0.0.1:4352"
+func (s *Server) Notify(target string, command string) error {
+	s.mu.Lock()
+	s.settle()
+	var value string
+	switch command {
+	case "LKUP":
+		value = s.profile.MAC
+	case "ERST":
+		value = encodeErrorStatus(s.errorStatus)
+	case "POWR":
+		value = strconv.Itoa(int(s.power))
+	case "INPT":
+		value = s.input
+	default:
+		s.mu.Unlock()
+		return errors.New("pjlinktest: " + command + " is not a status notification")
+	}
+	s.mu.Unlock()
+
+	conn, err := net.Dial("udp", target)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("%2" + command + "=" + value + "\r"))
+	return err
+}
+
+// ListenSearch answers Class 2 searches (SRCH) on the UDP address addr, e.g. ":4352", with ACKN and the MAC address
+func (s *Server) ListenSearch(addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		<-s.closing
+		conn.Close()
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		buf := make([]byte, 1024)
+		for {
+			n, from, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if strings.TrimRight(string(buf[:n]), "\r\n") != "%2SRCH" {
+				continue
+			}
+			s.logf("%s search", from)
+			conn.WriteTo([]byte("%2ACKN="+s.profile.MAC+"\r"), from)
+		}
+	}()
+	return nil
+}
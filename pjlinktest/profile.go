@@ -0,0 +1,70 @@
+package pjlinktest
+
+import "time"
+
+// Profile describes the device a Server pretends to be, zero values get sensible defaults
+type Profile struct {
+	Name         string // NAME
+	Manufacturer string // INF1
+	Model        string // INF2
+	Info         string // INFO
+	Class        int    // CLSS, 1 or 2
+	Password     string // empty disables authentication
+	MAC          string // reported by LKUP and ACKN, Class 2
+
+	Inputs     []string          // INST, raw inputs such as "11" or "3A", the first one is selected at start
+	InputNames map[string]string // INNM by raw input, Class 2
+	Lamps      int               // number of lamps reported by LAMP
+	LampHours  int               // initial hours of every lamp
+
+	// Class 2
+	SerialNumber    string   // SNUM
+	SoftwareVersion string   // SVER
+	Resolution      string   // IRES while powered on and RRES, e.g. "1920x1080"
+	LampModels      []string // RLMP
+	FilterModels    []string // RFIL
+
+	WarmUp      time.Duration // time spent in warm-up after POWR 1
+	CoolDown    time.Duration // time spent cooling after POWR 0
+	IdleTimeout time.Duration // connections without a command are dropped, 30 seconds by default
+}
+
+func (p Profile) withDefaults() Profile {
+	if p.Name == "" {
+		p.Name = "pjlinktest"
+	}
+	if p.Manufacturer == "" {
+		p.Manufacturer = "PJLinkTest"
+	}
+	if p.Model == "" {
+		p.Model = "Emulator"
+	}
+	if p.Info == "" {
+		p.Info = "pjlinktest emulated projector"
+	}
+	if p.Class == 0 {
+		p.Class = 1
+	}
+	if len(p.Inputs) == 0 {
+		p.Inputs = []string{"11", "31", "32"}
+	}
+	if p.Lamps == 0 {
+		p.Lamps = 1
+	}
+	if p.MAC == "" {
+		p.MAC = "00:00:5e:00:53:01"
+	}
+	if p.SerialNumber == "" {
+		p.SerialNumber = "0000000000"
+	}
+	if p.SoftwareVersion == "" {
+		p.SoftwareVersion = "1.0"
+	}
+	if p.Resolution == "" {
+		p.Resolution = "1920x1080"
+	}
+	if p.IdleTimeout == 0 {
+		p.IdleTimeout = defaultIdleTimeout
+	}
+	return p
+}
@@ -0,0 +1,241 @@
+// Package pjlinktest provides an in-process PJLink device for tests and demos.
+//
+// A Server speaks the PJLink wire format over TCP, authenticates like a real projector
+// and keeps mutable power, input, mute, lamp and error state:
+//
+//	srv := pjlinktest.NewServer(pjlinktest.Profile{Password: "secret"})
+//	defer srv.Close()
+//	proj := srv.Projector()
+//	err := proj.TurnOn()
+package pjlinktest
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+// projectors drop the connection after 30 seconds without a command
+const defaultIdleTimeout = 30 * time.Second
+
+// transition of a state set directly, it doesn't end on its own
+var farFuture = time.Unix(1<<62, 0)
+
+// Server is a PJLink device listening on TCP
+type Server struct {
+	// logs connections and commands if set, must be set before the Server is started
+	Logger *log.Logger
+
+	profile Profile
+
+	listener  net.Listener
+	wg        sync.WaitGroup
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	mu          sync.Mutex
+	conns       map[net.Conn]struct{}
+	power       pjlink.PowerState
+	transition  time.Time // end of the current warm-up or cooling
+	input       string
+	mute        pjlink.AVMute
+	freeze      bool
+	lamps       []pjlink.Lamp
+	errorStatus pjlink.ErrorStatus
+	filterHours int
+	volume      map[string]int // SVOL and MVOL steps relative to the start
+	failure     bool
+	requests    []string // the last maxRequests commands
+	received    int
+}
+
+// NewServer starts a Server on a loopback port, like httptest.NewServer it panics if it can't listen
+func NewServer(profile Profile) *Server {
+	s := NewUnstartedServer(profile)
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns a Server that still has to be started with Start or Listen
+func NewUnstartedServer(profile Profile) *Server {
+	profile = profile.withDefaults()
+
+	lamps := make([]pjlink.Lamp, profile.Lamps)
+	for i := range lamps {
+		lamps[i].Hours = profile.LampHours
+	}
+
+	return &Server{
+		profile: profile,
+		closing: make(chan struct{}),
+		conns:   make(map[net.Conn]struct{}),
+		input:   profile.Inputs[0],
+		lamps:   lamps,
+		volume:  make(map[string]int),
+	}
+}
+
+// Start listens on a free loopback port, it panics if it can't listen
+func (s *Server) Start() {
+	if err := s.Listen("127.0.0.1:0"); err != nil {
+		panic("pjlinktest: failed to listen: " + err.Error())
+	}
+}
+
+// Listen starts serving on addr, e.g. ":4352"
+func (s *Server) Listen(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	s.wg.Add(1)
+	go s.serve()
+	return nil
+}
+
+// Addr is the host:port the Server listens on
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Projector returns a client configured for this Server
+func (s *Server) Projector() *pjlink.PJProjector {
+	host, port, _ := net.SplitHostPort(s.Addr())
+	pr := pjlink.NewProjector(host, s.profile.Password)
+	pr.Port = port
+	pr.Class = s.profile.Class
+	return pr
+}
+
+// Close stops listening and drops all connections. It may be called more than once and on a
+// Server that was never started.
+func (s *Server) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		close(s.closing)
+		s.mu.Unlock()
+		if s.listener != nil {
+			err = s.listener.Close()
+		}
+
+		s.mu.Lock()
+		for conn := range s.conns {
+			conn.Close()
+		}
+		s.mu.Unlock()
+	})
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-s.closing:
+				return
+			default:
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return
+		}
+
+		s.mu.Lock()
+		select {
+		case <-s.closing:
+			s.mu.Unlock()
+			conn.Close()
+			return
+		default:
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// runs one connection: greeting, authentication of the first command and the command loop
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	s.logf("%s connected", conn.RemoteAddr())
+	defer s.logf("%s disconnected", conn.RemoteAddr())
+
+	seed := ""
+	if s.profile.Password == "" {
+		conn.Write([]byte("PJLINK 0\r"))
+	} else {
+		seed = newSeed()
+		conn.Write([]byte("PJLINK 1 " + seed + "\r"))
+	}
+
+	reader := bufio.NewReader(conn)
+	for {
+		conn.SetReadDeadline(time.Now().Add(s.profile.IdleTimeout))
+		line, err := reader.ReadString('\r')
+		if err != nil {
+			return
+		}
+		line = strings.TrimLeft(strings.TrimSuffix(line, "\r"), "\n")
+
+		if seed != "" {
+			// only the first command of a connection carries the digest
+			expected := digest(seed, s.profile.Password)
+			if !strings.HasPrefix(line, expected) {
+				s.logf("%s authentication failed", conn.RemoteAddr())
+				conn.Write([]byte("PJLINK ERRA\r"))
+				return
+			}
+			line = line[len(expected):]
+			seed = ""
+		}
+
+		response := s.respond(line)
+		s.logf("%s %q -> %q", conn.RemoteAddr(), line, response)
+		conn.Write([]byte(response + "\r"))
+	}
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.Logger != nil {
+		s.Logger.Printf(format, args...)
+	}
+}
+
+// the digest a client has to send, md5 of seed and password as lowercase hex
+func digest(seed string, password string) string {
+	hash := md5.Sum([]byte(seed + password))
+	return hex.EncodeToString(hash[:])
+}
+
+func newSeed() string {
+	seed := make([]byte, 4)
+	rand.Read(seed)
+	return hex.EncodeToString(seed)
+}
@@ -0,0 +1,241 @@
+package pjlinktest
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+)
+
+// connects to srv and returns the greeting without the trailing \r
+func dialRaw(t *testing.T, srv *Server) (net.Conn, *bufio.Reader, string) {
+	t.Helper()
+	conn, err := net.Dial("tcp", srv.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	reader := bufio.NewReader(conn)
+	return conn, reader, readLine(t, reader)
+}
+
+func readLine(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	line, err := reader.ReadString('\r')
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSuffix(line, "\r")
+}
+
+func TestGreetingWithoutAuthentication(t *testing.T) {
+	srv := NewServer(Profile{})
+	defer srv.Close()
+
+	conn, reader, greeting := dialRaw(t, srv)
+	if greeting != "PJLINK 0" {
+		t.Fatalf("greeting = %q, want PJLINK 0", greeting)
+	}
+	conn.Write([]byte("%1POWR ?\r"))
+	if got := readLine(t, reader); got != "%1POWR=0" {
+		t.Errorf("POWR ? = %q, want %%1POWR=0", got)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	srv := NewServer(Profile{Password: "JBMIAProjectorLink"})
+	defer srv.Close()
+
+	t.Run("digest", func(t *testing.T) {
+		conn, reader, greeting := dialRaw(t, srv)
+		seed, ok := strings.CutPrefix(greeting, "PJLINK 1 ")
+		if !ok || len(seed) != 8 {
+			t.Fatalf("greeting = %q, want PJLINK 1 and an 8 character seed", greeting)
+		}
+		conn.Write([]byte(digest(seed, "JBMIAProjectorLink") + "%1POWR ?\r"))
+		if got := readLine(t, reader); got != "%1POWR=0" {
+			t.Errorf("POWR ? = %q, want %%1POWR=0", got)
+		}
+		// only the first command carries the digest
+		conn.Write([]byte("%1NAME ?\r"))
+		if got := readLine(t, reader); got != "%1NAME=pjlinktest" {
+			t.Errorf("NAME ? = %q, want %%1NAME=pjlinktest", got)
+		}
+	})
+
+	t.Run("bad digest", func(t *testing.T) {
+		conn, reader, greeting := dialRaw(t, srv)
+		seed := strings.TrimPrefix(greeting, "PJLINK 1 ")
+		conn.Write([]byte(digest(seed, "wrong") + "%1POWR ?\r"))
+		if got := readLine(t, reader); got != "PJLINK ERRA" {
+			t.Errorf("answer = %q, want PJLINK ERRA", got)
+		}
+		if _, err := reader.ReadString('\r'); err == nil {
+			t.Error("connection still open after ERRA")
+		}
+	})
+
+	t.Run("missing digest", func(t *testing.T) {
+		conn, reader, _ := dialRaw(t, srv)
+		conn.Write([]byte("%1POWR ?\r"))
+		if got := readLine(t, reader); got != "PJLINK ERRA" {
+			t.Errorf("answer = %q, want PJLINK ERRA", got)
+		}
+	})
+
+	t.Run("client", func(t *testing.T) {
+		pr := srv.Projector()
+		if _, err := pr.Power(); err != nil {
+			t.Errorf("Power() = %v", err)
+		}
+		pr.Password = "wrong"
+		if _, err := pr.Power(); !errors.Is(err, pjlink.ErrAuthentication) {
+			t.Errorf("Power() with a wrong password = %v, want ERRA", err)
+		}
+	})
+}
+
+func TestWarmUpAndCoolDown(t *testing.T) {
+	srv := NewServer(Profile{WarmUp: 200 * time.Millisecond, CoolDown: 200 * time.Millisecond})
+	defer srv.Close()
+	pr := srv.Projector()
+
+	if err := pr.TurnOn(); err != nil {
+		t.Fatal(err)
+	}
+	if state, err := pr.Power(); err != nil || state != pjlink.PowerWarmUp {
+		t.Fatalf("Power() = %v, %v, want warm-up", state, err)
+	}
+	if err := pr.TurnOff(); !errors.Is(err, pjlink.ErrUnavailableTime) {
+		t.Errorf("TurnOff() while warming up = %v, want ERR3", err)
+	}
+	if err := pr.SetProperty("INPT", "32"); !errors.Is(err, pjlink.ErrUnavailableTime) {
+		t.Errorf("INPT 32 while warming up = %v, want ERR3", err)
+	}
+
+	waitForPower(t, srv, pjlink.PowerOn)
+	if lamps := srv.Lamps(); !lamps[0].On {
+		t.Error("lamp is off after warm-up")
+	}
+
+	if err := pr.TurnOff(); err != nil {
+		t.Fatal(err)
+	}
+	if state, err := pr.Power(); err != nil || state != pjlink.PowerCooling {
+		t.Fatalf("Power() = %v, %v, want cooling", state, err)
+	}
+	if err := pr.TurnOn(); !errors.Is(err, pjlink.ErrUnavailableTime) {
+		t.Errorf("TurnOn() while cooling = %v, want ERR3", err)
+	}
+	waitForPower(t, srv, pjlink.PowerOff)
+}
+
+func waitForPower(t *testing.T, srv *Server, target pjlink.PowerState) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for srv.Power() != target {
+		if time.Now().After(deadline) {
+			t.Fatalf("power is %v, want %v", srv.Power(), target)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStateMutation(t *testing.T) {
+	srv := NewServer(Profile{Class: 2, Inputs: []string{"11", "31", "3A"}, Lamps: 2})
+	defer srv.Close()
+	pr := srv.Projector()
+
+	if err := pr.SetInput(pjlink.Input{Type: pjlink.InputDigital, Index: 10}); !errors.Is(err, pjlink.ErrUnavailableTime) {
+		t.Errorf("SetInput() in standby = %v, want ERR3", err)
+	}
+	srv.SetPower(pjlink.PowerOn)
+
+	if err := pr.SetInput(pjlink.Input{Type: pjlink.InputDigital, Index: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if srv.Input() != "3A" {
+		t.Errorf("input = %s, want 3A", srv.Input())
+	}
+	if err := pr.SetVideoMute(true); err != nil {
+		t.Fatal(err)
+	}
+	if mute := srv.AVMute(); mute != (pjlink.AVMute{Video: true}) {
+		t.Errorf("mute = %+v, want video", mute)
+	}
+	if err := pr.SetFreeze(true); err != nil || !srv.Freeze() {
+		t.Errorf("SetFreeze(true) = %v, freeze = %t", err, srv.Freeze())
+	}
+	pr.SpeakerVolumeUp()
+	pr.SpeakerVolumeUp()
+	pr.MicrophoneVolumeDown()
+	if srv.Volume("SVOL") != 2 || srv.Volume("MVOL") != -1 {
+		t.Errorf("volume = %d/%d, want 2/-1", srv.Volume("SVOL"), srv.Volume("MVOL"))
+	}
+
+	lamps := []pjlink.Lamp{{Hours: 1200, On: true}, {Hours: 30, On: false}}
+	srv.SetLamps(lamps)
+	if got, err := pr.Lamps(); err != nil || !reflect.DeepEqual(got, lamps) {
+		t.Errorf("Lamps() = %+v, %v, want %+v", got, err, lamps)
+	}
+
+	status := pjlink.ErrorStatus{Fan: pjlink.ErrorLevelWarning, Filter: pjlink.ErrorLevelError}
+	srv.SetErrorStatus(status)
+	if got, err := pr.ErrorStatus(); err != nil || got != status {
+		t.Errorf("ErrorStatus() = %+v, %v, want %+v", got, err, status)
+	}
+
+	srv.SetFailure(true)
+	if _, err := pr.Power(); !errors.Is(err, pjlink.ErrDeviceFailure) {
+		t.Errorf("Power() on failure = %v, want ERR4", err)
+	}
+
+	requests := srv.Requests()
+	if len(requests) == 0 || requests[len(requests)-1] != "%1POWR ?" {
+		t.Errorf("last request = %q, want %%1POWR ?", requests)
+	}
+}
+
+func TestClass1RejectsClass2(t *testing.T) {
+	srv := NewServer(Profile{})
+	defer srv.Close()
+
+	if _, err := srv.Projector().GetSerialNumber(); !errors.Is(err, pjlink.ErrUndefinedCommand) {
+		t.Errorf("SNUM on Class 1 = %v, want ERR1", err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	srv := NewServer(Profile{})
+	if err := srv.Close(); err != nil {
+		t.Errorf("first Close() = %v", err)
+	}
+	srv.Close()
+
+	if err := NewUnstartedServer(Profile{}).Close(); err != nil {
+		t.Errorf("Close() of an unstarted Server = %v", err)
+	}
+}
+
+func TestRequestsAreCapped(t *testing.T) {
+	srv := NewUnstartedServer(Profile{})
+	for i := 0; i < maxRequests+10; i++ {
+		srv.respond("%1INPT " + strconv.Itoa(i))
+	}
+
+	requests := srv.Requests()
+	if len(requests) != maxRequests || requests[0] != "%1INPT 10" || requests[len(requests)-1] != "%1INPT "+strconv.Itoa(maxRequests+9) {
+		t.Errorf("Requests() = %d from %q to %q, want the last %d", len(requests), requests[0], requests[len(requests)-1], maxRequests)
+	}
+	if got := srv.RequestCount(); got != maxRequests+10 {
+		t.Errorf("RequestCount() = %d, want %d", got, maxRequests+10)
+	}
+}
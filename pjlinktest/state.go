@@ -0,0 +1,119 @@
+package pjlinktest
+
+import (
+	"github.com/LightInstruments/pjlink"
+)
+
+// Power returns the current power state, warm-up and cooling end after the times of the Profile
+func (s *Server) Power() pjlink.PowerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settle()
+	return s.power
+}
+
+// SetPower changes the power state without going through warm-up or cooling.
+// PowerWarmUp and PowerCooling stay until the next POWR command.
+func (s *Server) SetPower(state pjlink.PowerState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.power = state
+	s.transition = farFuture
+	switch state {
+	case pjlink.PowerOn:
+		s.setLamps(true)
+	case pjlink.PowerOff:
+		s.setLamps(false)
+	}
+}
+
+// Input returns the selected raw input, e.g. "31"
+func (s *Server) Input() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.input
+}
+
+// SetInput selects a raw input regardless of the power state
+func (s *Server) SetInput(raw string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.input = raw
+}
+
+func (s *Server) AVMute() pjlink.AVMute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mute
+}
+
+func (s *Server) SetAVMute(mute pjlink.AVMute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mute = mute
+}
+
+func (s *Server) Freeze() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.freeze
+}
+
+func (s *Server) Lamps() []pjlink.Lamp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]pjlink.Lamp(nil), s.lamps...)
+}
+
+// SetLamps replaces the lamps reported by LAMP, their number included
+func (s *Server) SetLamps(lamps []pjlink.Lamp) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lamps = append([]pjlink.Lamp(nil), lamps...)
+}
+
+func (s *Server) ErrorStatus() pjlink.ErrorStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.errorStatus
+}
+
+func (s *Server) SetErrorStatus(status pjlink.ErrorStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errorStatus = status
+}
+
+func (s *Server) SetFilterHours(hours int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.filterHours = hours
+}
+
+// Volume returns the steps SVOL or MVOL moved the volume since the start
+func (s *Server) Volume(command string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.volume[command]
+}
+
+// SetFailure makes every command answer ERR4 (projector/display failure)
+func (s *Server) SetFailure(failure bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failure = failure
+}
+
+// Requests returns the last 1000 commands received, without the authentication digest
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// RequestCount returns the number of commands received, including those Requests no longer returns
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.received
+}
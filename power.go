@@ -0,0 +1,136 @@
+package pjlink
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// PowerState as reported by POWR, the values match the raw responses "0" to "3"
+type PowerState int
+
+const (
+	PowerOff     PowerState = iota // standby
+	PowerOn                        // lamp on
+	PowerCooling                   // cooling down after being turned off
+	PowerWarmUp                    // warming up after being turned on
+)
+
+// how often WaitForPower asks the Projector for its power state if PowerPollInterval is zero
+const defaultPowerPollInterval = time.Second
+
+func (state PowerState) String() string {
+	switch state {
+	case PowerOff:
+		return "off"
+	case PowerOn:
+		return "on"
+	case PowerCooling:
+		return "cooling"
+	case PowerWarmUp:
+		return "warm-up"
+	}
+	return "unknown"
+}
+
+// MarshalText encodes the state by its name, e.g. "warm-up", so JSON carries names instead of numbers
+func (state PowerState) MarshalText() ([]byte, error) {
+	return []byte(state.String()), nil
+}
+
+// UnmarshalText accepts the names of String as well as the raw values "0" to "3"
+func (state *PowerState) UnmarshalText(text []byte) error {
+	for _, candidate := range []PowerState{PowerOff, PowerOn, PowerCooling, PowerWarmUp} {
+		if string(text) == candidate.String() {
+			*state = candidate
+			return nil
+		}
+	}
+	parsed, err := parsePowerState(string(text))
+	if err != nil {
+		return errors.New("unknown power state " + string(text))
+	}
+	*state = parsed
+	return nil
+}
+
+func parsePowerState(raw string) (PowerState, error) {
+	switch raw {
+	case "0":
+		return PowerOff, nil
+	case "1":
+		return PowerOn, nil
+	case "2":
+		return PowerCooling, nil
+	case "3":
+		return PowerWarmUp, nil
+	}
+	return 0, &ResponseError{Command: "POWR", Response: raw, Reason: "Invalid power status: " + raw}
+}
+
+func (pr *PJProjector) Power() (PowerState, error) {
+	return pr.PowerContext(context.Background())
+}
+
+func (pr *PJProjector) PowerContext(ctx context.Context) (PowerState, error) {
+	resp, err := pr.GetPowerStatusContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := resp.Err(); err != nil {
+		return 0, pr.annotate(err, "POWR")
+	}
+	state, err := parsePowerState(resp.Response[0])
+	return state, pr.annotate(err, "POWR")
+}
+
+// PowerTransitionError is returned by WaitForPower when the Projector is heading away from the target,
+// cooling down while waiting for on or warming up while waiting for off
+type PowerTransitionError struct {
+	Address string
+	Target  PowerState
+	State   PowerState
+}
+
+func (e *PowerTransitionError) Error() string {
+	return describe(e.Address, "POWR", "Waiting for "+e.Target.String()+" but the projector is in "+e.State.String())
+}
+
+// the transition that ends in the opposite of a target of WaitForPower
+var awayFrom = map[PowerState]PowerState{
+	PowerOn:  PowerCooling,
+	PowerOff: PowerWarmUp,
+}
+
+// Polls the power state every PowerPollInterval until it reaches target (PowerOn or PowerOff), passing through
+// warm-up and cooling. Returns how long the transition took. The Projector answering ERR3 (unavailable time) is retried.
+// A transition away from target ends the wait with a *PowerTransitionError, the opposite state itself does not,
+// since projectors may still report it right after the power command.
+func (pr *PJProjector) WaitForPower(ctx context.Context, target PowerState) (time.Duration, error) {
+	if target != PowerOn && target != PowerOff {
+		return 0, pr.annotate(&RequestError{Reason: "Can only wait for power on or power off"}, "POWR")
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(withDefault(pr.PowerPollInterval, defaultPowerPollInterval))
+	defer ticker.Stop()
+
+	for {
+		state, err := pr.PowerContext(ctx)
+		if err != nil && !errors.Is(err, ErrUnavailableTime) {
+			return time.Since(start), err
+		}
+		if err == nil && state == target {
+			return time.Since(start), nil
+		}
+		if err == nil && state == awayFrom[target] {
+			return time.Since(start), &PowerTransitionError{Address: pr.hostPort(), Target: target, State: state}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return time.Since(start), ctx.Err()
+		}
+	}
+}
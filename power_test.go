@@ -0,0 +1,89 @@
+package pjlink_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+func TestPowerStateJSON(t *testing.T) {
+	for _, state := range []pjlink.PowerState{pjlink.PowerOff, pjlink.PowerOn, pjlink.PowerCooling, pjlink.PowerWarmUp} {
+		data, err := json.Marshal(state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `"`+state.String()+`"` {
+			t.Errorf("Marshal(%v) = %s", state, data)
+		}
+		var decoded pjlink.PowerState
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != state {
+			t.Errorf("Unmarshal(%s) = %v, %v", data, decoded, err)
+		}
+	}
+
+	var state pjlink.PowerState
+	if err := state.UnmarshalText([]byte("3")); err != nil || state != pjlink.PowerWarmUp {
+		t.Errorf("UnmarshalText(3) = %v, %v", state, err)
+	}
+	if err := state.UnmarshalText([]byte("standby")); err == nil {
+		t.Error("UnmarshalText(standby) succeeded")
+	}
+}
+
+func TestWaitForPower(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{WarmUp: 100 * time.Millisecond})
+	defer srv.Close()
+
+	pr := srv.Projector()
+	pr.PowerPollInterval = 10 * time.Millisecond
+	if err := pr.TurnOn(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	took, err := pr.WaitForPower(ctx, pjlink.PowerOn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if took > time.Second {
+		t.Errorf("waiting took %v, the interval of 10ms was not used", took)
+	}
+}
+
+func TestWaitForPowerAwayFromTarget(t *testing.T) {
+	tests := []struct {
+		state  pjlink.PowerState
+		target pjlink.PowerState
+	}{
+		{pjlink.PowerCooling, pjlink.PowerOn},
+		{pjlink.PowerWarmUp, pjlink.PowerOff},
+	}
+	for _, test := range tests {
+		srv := pjlinktest.NewServer(pjlinktest.Profile{})
+		srv.SetPower(test.state)
+		pr := srv.Projector()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		took, err := pr.WaitForPower(ctx, test.target)
+		cancel()
+		srv.Close()
+
+		var transitionErr *pjlink.PowerTransitionError
+		if !errors.As(err, &transitionErr) || transitionErr.State != test.state || transitionErr.Target != test.target {
+			t.Errorf("WaitForPower(%v) in %v = %v, want a PowerTransitionError", test.target, test.state, err)
+			continue
+		}
+		if transitionErr.Address != srv.Addr() {
+			t.Errorf("address = %q, want %q", transitionErr.Address, srv.Addr())
+		}
+		if took > time.Second {
+			t.Errorf("WaitForPower(%v) in %v took %v, want it to return after the first poll", test.target, test.state, took)
+		}
+	}
+}
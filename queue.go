@@ -0,0 +1,59 @@
+package pjlink
+
+import (
+	"context"
+	"sync"
+)
+
+// Many PJLink devices accept only one connection at a time, so requests to the same device are queued.
+// The queue is shared by all Projectors pointing to the same address and port and removed once no
+// request is in flight or waiting, so addresses used once don't pile up.
+var (
+	deviceQueuesMu sync.Mutex
+	deviceQueues   = make(map[string]*deviceQueue) // host:port
+)
+
+type deviceQueue struct {
+	slot  chan struct{} // holds a token while a request is in flight
+	users int           // requests in flight or waiting, guarded by deviceQueuesMu
+}
+
+// the queue of the device with one more user, every call must be followed by leaveDeviceQueue
+func joinDeviceQueue(hostPort string) *deviceQueue {
+	deviceQueuesMu.Lock()
+	defer deviceQueuesMu.Unlock()
+
+	queue, ok := deviceQueues[hostPort]
+	if !ok {
+		queue = &deviceQueue{slot: make(chan struct{}, 1)}
+		deviceQueues[hostPort] = queue
+	}
+	queue.users++
+	return queue
+}
+
+func leaveDeviceQueue(hostPort string, queue *deviceQueue) {
+	deviceQueuesMu.Lock()
+	defer deviceQueuesMu.Unlock()
+
+	queue.users--
+	if queue.users == 0 {
+		delete(deviceQueues, hostPort)
+	}
+}
+
+// waits until no other request to the device is in flight, the returned function hands the device to the next one
+func (pr *PJProjector) acquire(ctx context.Context) (release func(), err error) {
+	hostPort := pr.hostPort()
+	queue := joinDeviceQueue(hostPort)
+	select {
+	case queue.slot <- struct{}{}:
+		return func() {
+			<-queue.slot
+			leaveDeviceQueue(hostPort, queue)
+		}, nil
+	case <-ctx.Done():
+		leaveDeviceQueue(hostPort, queue)
+		return func() {}, ctx.Err()
+	}
+}
@@ -0,0 +1,40 @@
+package pjlink
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func deviceQueueCount() int {
+	deviceQueuesMu.Lock()
+	defer deviceQueuesMu.Unlock()
+	return len(deviceQueues)
+}
+
+func TestDeviceQueuesArePruned(t *testing.T) {
+	before := deviceQueueCount()
+
+	pr := scriptedDevice(t, map[string]string{"%1POWR ?": "%1POWR=1"})
+	if _, err := pr.GetPowerStatus(); err != nil {
+		t.Fatal(err)
+	}
+	if got := deviceQueueCount(); got != before {
+		t.Errorf("%d device queues after the request, want %d", got, before)
+	}
+
+	// a request that gives up waiting leaves no queue behind either
+	release, err := pr.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pr.acquire(ctx); err == nil {
+		t.Fatal("second acquire succeeded while the device was busy")
+	}
+	release()
+	if got := deviceQueueCount(); got != before {
+		t.Errorf("%d device queues after a cancelled request, want %d", got, before)
+	}
+}
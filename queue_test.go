@@ -0,0 +1,96 @@
+package pjlink_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+func TestConcurrentRequestsAreSerialized(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Password: "secret"})
+	defer srv.Close()
+	srv.SetPower(pjlink.PowerOn)
+
+	// connections open to the device, counted on the wire between connect and close
+	var open, peak atomic.Int32
+	pr := srv.Projector()
+	pr.Tracer = pjlink.TracerFunc(func(event pjlink.TraceEvent) {
+		switch {
+		case event.Kind == pjlink.TraceConnect && event.Err == nil:
+			n := open.Add(1)
+			for {
+				max := peak.Load()
+				if n <= max || peak.CompareAndSwap(max, n) {
+					break
+				}
+			}
+		case event.Kind == pjlink.TraceClose:
+			open.Add(-1)
+		}
+	})
+
+	const workers, rounds = 8, 5
+	var wg sync.WaitGroup
+	errs := make(chan error, 3*workers*rounds)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < rounds; j++ {
+				if _, err := pr.GetPowerStatus(); err != nil {
+					errs <- err
+				}
+				if err := pr.SetProperty("INPT", "31"); err != nil {
+					errs <- err
+				}
+				if _, err := pr.GetProperty("NAME"); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if got := peak.Load(); got != 1 {
+		t.Errorf("device saw %d connections at a time, want 1", got)
+	}
+	if got := srv.RequestCount(); got != 3*workers*rounds {
+		t.Errorf("device received %d requests, want %d", got, 3*workers*rounds)
+	}
+	if srv.Input() != "31" {
+		t.Errorf("input = %s, want 31", srv.Input())
+	}
+}
+
+func TestConcurrentRequestsInSession(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{})
+	defer srv.Close()
+
+	pr := srv.Projector()
+	pr.Session = true
+	defer pr.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				if _, err := pr.GetPowerStatus(); err != nil {
+					t.Error(err)
+				}
+				if _, err := pr.GetProperty("NAME"); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
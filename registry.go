@@ -0,0 +1,141 @@
+package pjlink
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RegistryEntry maps the MAC address of a projector to its current IP address
+type RegistryEntry struct {
+	MAC      string    `json:"mac"`
+	Address  string    `json:"address"`
+	Port     string    `json:"port,omitempty"`  // PJLink port, 4352 if not set
+	Class    int       `json:"class,omitempty"` // class the projector announced itself with, 0 if unknown
+	LastSeen time.Time `json:"last-seen"`
+}
+
+// Registry keeps track of projectors by MAC address, so a new address handed out by DHCP
+// is picked up from the LKUP announcement a Class 2 projector sends when it links up.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[string]RegistryEntry // by MAC
+}
+
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[string]RegistryEntry)}
+}
+
+// Record stores entry under its MAC address and reports whether the address or port is new or changed.
+// A Port or Class left empty keeps the one known for the MAC address, so a projector on a
+// custom port stays reachable after DHCP moved it.
+func (r *Registry) Record(entry RegistryEntry) (changed bool) {
+	hw, err := net.ParseMAC(entry.MAC)
+	if err != nil {
+		return false
+	}
+	entry.MAC = strings.ToLower(hw.String())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, known := r.entries[entry.MAC]
+	if entry.Port == "" {
+		entry.Port = old.Port
+	}
+	if entry.Class == 0 {
+		entry.Class = old.Class
+	}
+	r.entries[entry.MAC] = entry
+	return !known || old.Address != entry.Address || old.Port != entry.Port
+}
+
+// RecordEvent records a LinkUpEvent, other notifications refresh LastSeen of the projector that sent them
+func (r *Registry) RecordEvent(event Event) (changed bool) {
+	if linkUp, ok := event.(*LinkUpEvent); ok {
+		// LKUP is a Class 2 notification, its header carries the class
+		class := 0
+		if linkUp.Response != nil {
+			class, _ = strconv.Atoi(linkUp.Response.Class)
+		}
+		return r.Record(RegistryEntry{MAC: linkUp.MAC, Address: linkUp.Source(), Class: class, LastSeen: linkUp.ReceivedAt()})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for mac, entry := range r.entries {
+		if entry.Address == event.Source() {
+			entry.LastSeen = event.ReceivedAt()
+			r.entries[mac] = entry
+		}
+	}
+	return false
+}
+
+// RecordDiscovered records the answers of Discover
+func (r *Registry) RecordDiscovered(found []DiscoveredProjector) {
+	now := time.Now()
+	for _, projector := range found {
+		r.Record(RegistryEntry{MAC: projector.MAC, Address: projector.Address, Class: projector.Class, LastSeen: now})
+	}
+}
+
+// Watch records all events of the Listener until it is closed or ctx is done
+func (r *Registry) Watch(ctx context.Context, l *Listener) {
+	for {
+		select {
+		case event, ok := <-l.Events():
+			if !ok {
+				return
+			}
+			r.RecordEvent(event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Lookup returns the entry of mac
+func (r *Registry) Lookup(mac string) (RegistryEntry, bool) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return RegistryEntry{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.entries[strings.ToLower(hw.String())]
+	return entry, ok
+}
+
+// Projector returns a client for the current address, port and class of mac
+func (r *Registry) Projector(mac string, password string) (*PJProjector, bool) {
+	entry, ok := r.Lookup(mac)
+	if !ok {
+		return nil, false
+	}
+	pr := NewProjector(entry.Address, password)
+	if entry.Port != "" {
+		pr.Port = entry.Port
+	}
+	pr.Class = entry.Class
+	return pr, true
+}
+
+// Entries lists all entries sorted by MAC address
+func (r *Registry) Entries() []RegistryEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]RegistryEntry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].MAC < entries[j].MAC })
+	return entries
+}
@@ -0,0 +1,52 @@
+package pjlink
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestRegistryKeepsPortAndClass(t *testing.T) {
+	r := NewRegistry()
+	if !r.Record(RegistryEntry{MAC: "00:00:5E:00:53:01", Address: "10.0.0.5", Port: "14352", LastSeen: time.Now()}) {
+		t.Error("first Record() reported no change")
+	}
+
+	// the projector got a new address from DHCP and announced itself with LKUP
+	addr := &net.UDPAddr{IP: net.ParseIP("10.0.0.9"), Port: 4352}
+	event, err := parseNotification("%2LKUP=00:00:5e:00:53:01\r", addr, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.RecordEvent(event) {
+		t.Error("RecordEvent() didn't report the new address")
+	}
+
+	entry, ok := r.Lookup("00-00-5e-00-53-01")
+	if !ok {
+		t.Fatal("entry not found")
+	}
+	want := RegistryEntry{MAC: "00:00:5e:00:53:01", Address: "10.0.0.9", Port: "14352", Class: 2}
+	if entry.MAC != want.MAC || entry.Address != want.Address || entry.Port != want.Port || entry.Class != want.Class {
+		t.Errorf("entry = %+v, want %+v", entry, want)
+	}
+
+	pr, ok := r.Projector("00:00:5e:00:53:01", "secret")
+	if !ok {
+		t.Fatal("Projector() found no entry")
+	}
+	if pr.Address != "10.0.0.9" || pr.Port != "14352" || pr.Class != 2 {
+		t.Errorf("Projector() = %v, want 10.0.0.9:14352 Class 2", pr)
+	}
+
+	if r.Record(RegistryEntry{MAC: "00:00:5e:00:53:01", Address: "10.0.0.9", LastSeen: time.Now()}) {
+		t.Error("Record() of the same address reported a change")
+	}
+}
+
+func TestNotificationTargetUnsupported(t *testing.T) {
+	if _, err := NewProjector("10.0.0.5", "").NotificationTarget(); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("NotificationTarget() = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,122 @@
+package pjlink_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+// counts the connections a projector opened and closed
+type connectionCounter struct {
+	mu     sync.Mutex
+	opened int
+	closed int
+}
+
+func (c *connectionCounter) Trace(event pjlink.TraceEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case event.Kind == pjlink.TraceConnect && event.Err == nil:
+		c.opened++
+	case event.Kind == pjlink.TraceClose:
+		c.closed++
+	}
+}
+
+func (c *connectionCounter) counts() (opened int, closed int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.opened, c.closed
+}
+
+func sessionProjector(srv *pjlinktest.Server) (*pjlink.PJProjector, *connectionCounter) {
+	counter := &connectionCounter{}
+	pr := srv.Projector()
+	pr.Session = true
+	pr.Tracer = counter
+	return pr, counter
+}
+
+func TestSessionIsReused(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Password: "secret"})
+	defer srv.Close()
+	pr, counter := sessionProjector(srv)
+
+	for i := 0; i < 5; i++ {
+		if _, err := pr.Power(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if opened, closed := counter.counts(); opened != 1 || closed != 0 {
+		t.Errorf("%d connections opened and %d closed for 5 requests, want one kept open", opened, closed)
+	}
+
+	pr.Close()
+	if _, closed := counter.counts(); closed != 1 {
+		t.Error("Close() didn't close the session")
+	}
+	// the projector can still be used after Close
+	if _, err := pr.Power(); err != nil {
+		t.Fatal(err)
+	}
+	pr.Close()
+}
+
+func TestSessionIdleExpiry(t *testing.T) {
+	defer pjlink.SetSessionMaxIdle(50 * time.Millisecond)()
+
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Password: "secret"})
+	defer srv.Close()
+	pr, counter := sessionProjector(srv)
+	defer pr.Close()
+
+	if _, err := pr.Power(); err != nil {
+		t.Fatal(err)
+	}
+	// within the limit the session is reused
+	if _, err := pr.Power(); err != nil {
+		t.Fatal(err)
+	}
+	if opened, _ := counter.counts(); opened != 1 {
+		t.Fatalf("%d connections before the session expired, want 1", opened)
+	}
+
+	// the device would still accept it, but an idle session is replaced before it drops it
+	time.Sleep(100 * time.Millisecond)
+	if _, err := pr.Power(); err != nil {
+		t.Fatal(err)
+	}
+	if opened, closed := counter.counts(); opened != 2 || closed != 1 {
+		t.Errorf("%d connections opened and %d closed after the idle limit, want the session replaced", opened, closed)
+	}
+}
+
+func TestSessionRedialsAfterDeviceClosed(t *testing.T) {
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Password: "secret", IdleTimeout: 50 * time.Millisecond})
+	defer srv.Close()
+	pr, counter := sessionProjector(srv)
+	defer pr.Close()
+
+	if _, err := pr.Power(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(150 * time.Millisecond) // the device drops the idle connection
+
+	// the broken session is noticed, redialed and authenticated again
+	if state, err := pr.Power(); err != nil || state != pjlink.PowerOff {
+		t.Fatalf("Power() after the device closed the session = %v, %v", state, err)
+	}
+	if opened, _ := counter.counts(); opened != 2 {
+		t.Errorf("%d connections, want a second one after the device closed the first", opened)
+	}
+	if _, err := pr.Power(); err != nil {
+		t.Fatal(err)
+	}
+	if opened, _ := counter.counts(); opened != 2 {
+		t.Errorf("%d connections, want the new session reused", opened)
+	}
+}
@@ -0,0 +1,114 @@
+package pjlink
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceKind is the step of a connection a TraceEvent describes
+type TraceKind int
+
+const (
+	TraceConnect  TraceKind = iota + 1 // TCP connection established or failed
+	TraceGreeting                      // PJLINK 0 or PJLINK 1 <seed> received
+	TraceSend                          // command written, the digest is redacted
+	TraceReceive                       // response line read
+	TraceClose                         // connection closed by this side
+)
+
+func (kind TraceKind) String() string {
+	switch kind {
+	case TraceConnect:
+		return "connect"
+	case TraceGreeting:
+		return "greeting"
+	case TraceSend:
+		return "send"
+	case TraceReceive:
+		return "receive"
+	case TraceClose:
+		return "close"
+	}
+	return "unknown"
+}
+
+// stands in for the authentication digest in traced data, it has the same length
+var redactedDigest = strings.Repeat("*", 32)
+
+// TraceEvent is a single step on the wire between a PJProjector and its device
+type TraceEvent struct {
+	Kind    TraceKind
+	Time    time.Time
+	Address string // host:port of the device
+	Data    []byte // bytes sent or received including the trailing \r, nil for connect and close
+	Err     error  // failure of the step, if any
+}
+
+// Tracer receives the wire traffic of a PJProjector. Trace is called on the goroutine of the
+// request and must not retain Data after it returns.
+type Tracer interface {
+	Trace(event TraceEvent)
+}
+
+// TracerFunc adapts a function to a Tracer
+type TracerFunc func(event TraceEvent)
+
+func (f TracerFunc) Trace(event TraceEvent) {
+	f(event)
+}
+
+// SlogTracer logs each event at debug level, e.g. msg="pjlink send" address=10.0.0.5:4352 data="%1POWR ?\r"
+func SlogTracer(logger *slog.Logger) Tracer {
+	return TracerFunc(func(event TraceEvent) {
+		ctx := context.Background()
+		if !logger.Enabled(ctx, slog.LevelDebug) {
+			return
+		}
+		record := slog.NewRecord(event.Time, slog.LevelDebug, "pjlink "+event.Kind.String(), 0)
+		record.AddAttrs(slog.String("address", event.Address))
+		if event.Data != nil {
+			record.AddAttrs(slog.String("data", string(event.Data)))
+		}
+		if event.Err != nil {
+			record.AddAttrs(slog.String("error", event.Err.Error()))
+		}
+		logger.Handler().Handle(ctx, record)
+	})
+}
+
+// HexDumpTracer writes a header line per event followed by a hex dump of its data, like
+//
+//	2024-05-01T10:00:00.000Z 10.0.0.5:4352 send 9 bytes
+//	00000000  25 31 50 4f 57 52 20 3f  0d                       |%1POWR ?.|
+func HexDumpTracer(w io.Writer) Tracer {
+	var mu sync.Mutex
+	return TracerFunc(func(event TraceEvent) {
+		header := fmt.Sprintf("%s %s %s", event.Time.Format("2006-01-02T15:04:05.000Z07:00"), event.Address, event.Kind)
+		if event.Data != nil {
+			header += fmt.Sprintf(" %d bytes", len(event.Data))
+		}
+		if event.Err != nil {
+			header += ": " + event.Err.Error()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintln(w, header)
+		if len(event.Data) > 0 {
+			io.WriteString(w, hex.Dump(event.Data))
+		}
+	})
+}
+
+// sends an event to the tracer of the connection if there is one
+func (conn *pjConn) trace(kind TraceKind, data []byte, err error) {
+	if conn.tracer != nil {
+		conn.tracer.Trace(TraceEvent{Kind: kind, Time: time.Now(), Address: conn.address, Data: data, Err: err})
+	}
+}
@@ -0,0 +1,85 @@
+package pjlink_test
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LightInstruments/pjlink"
+	"github.com/LightInstruments/pjlink/pjlinktest"
+)
+
+// keeps copies of the traced events
+type recorder struct {
+	mu     sync.Mutex
+	events []pjlink.TraceEvent
+}
+
+func (r *recorder) Trace(event pjlink.TraceEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event.Data = bytes.Clone(event.Data)
+	r.events = append(r.events, event)
+}
+
+func TestTraceRedactsDigest(t *testing.T) {
+	const password = "JBMIAProjectorLink"
+	srv := pjlinktest.NewServer(pjlinktest.Profile{Password: password})
+	defer srv.Close()
+
+	for _, session := range []bool{false, true} {
+		rec := &recorder{}
+		pr := srv.Projector()
+		pr.Session = session
+		pr.Tracer = rec
+		for i := 0; i < 3; i++ {
+			if _, err := pr.Power(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		pr.Close()
+
+		digests, sends := 0, 0
+		for _, event := range rec.events {
+			if event.Kind != pjlink.TraceGreeting {
+				continue
+			}
+			seed := strings.TrimSuffix(strings.TrimPrefix(string(event.Data), "PJLINK 1 "), "\r")
+			sum := md5.Sum([]byte(seed + password))
+			digest := hex.EncodeToString(sum[:])
+			digests++
+
+			for _, other := range rec.events {
+				if strings.Contains(strings.ToLower(string(other.Data)), digest) || strings.Contains(string(other.Data), password) {
+					t.Errorf("session %t: %s event %q contains the digest", session, other.Kind, other.Data)
+				}
+			}
+		}
+		for _, event := range rec.events {
+			if event.Kind == pjlink.TraceSend && strings.HasPrefix(string(event.Data), strings.Repeat("*", 32)+"%1POWR ?") {
+				sends++
+			}
+		}
+		if digests == 0 || sends != digests {
+			t.Errorf("session %t: %d greetings and %d redacted commands, want one redacted command per greeting", session, digests, sends)
+		}
+	}
+}
+
+func TestHexDumpTracer(t *testing.T) {
+	var out bytes.Buffer
+	tracer := pjlink.HexDumpTracer(&out)
+	tracer.Trace(pjlink.TraceEvent{Kind: pjlink.TraceSend, Time: time.Now(), Address: "10.0.0.5:4352", Data: []byte("%1POWR ?\r")})
+
+	lines := strings.Split(out.String(), "\n")
+	if !strings.HasSuffix(lines[0], "10.0.0.5:4352 send 9 bytes") {
+		t.Errorf("header = %q, want the address, kind and length", lines[0])
+	}
+	if !strings.Contains(lines[1], "25 31 50 4f 57 52 20 3f  0d") || !strings.HasSuffix(lines[1], "|%1POWR ?.|") {
+		t.Errorf("dump = %q", lines[1])
+	}
+}